@@ -10,4 +10,15 @@ resource "rancher2_user" "local_user" {
   password = "secret"
   name = "Jane Doe"
   description = "Your friendly colleague."
-}
\ No newline at end of file
+}
+# Make the local user a member of an existing project
+data "rancher2_project" "default" {
+  cluster_id = "local"
+  name = "Default"
+}
+
+resource "rancher2_project_role_template_binding" "local_user_member" {
+  project_id = "${data.rancher2_project.default.id}"
+  role_template_id = "project-member"
+  user_id = "${rancher2_user.local_user.id}"
+}
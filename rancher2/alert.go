@@ -0,0 +1,175 @@
+package rancher2
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/rancher/types/client/management/v3"
+)
+
+// Types of notifiers as referred to by the recipients of alerts.
+const (
+	notifierTypeSlack     = "slack"
+	notifierTypeEmail     = "email"
+	notifierTypePagerduty = "pagerduty"
+	notifierTypeWebhook   = "webhook"
+	notifierTypeWechat    = "wechat"
+)
+
+// notifierType derives the type of the given notifier from its configuration.
+func notifierType(n *notifier) string {
+	switch {
+	case n.SlackConfig != nil:
+		return notifierTypeSlack
+	case n.SMTPConfig != nil:
+		return notifierTypeEmail
+	case n.PagerdutyConfig != nil:
+		return notifierTypePagerduty
+	case n.WebhookConfig != nil:
+		return notifierTypeWebhook
+	case n.WechatConfig != nil:
+		return notifierTypeWechat
+	}
+	return ""
+}
+
+// alertTarget returns the single configured target of an alert out of the given mutually exclusive target
+// attributes, along with the name of the attribute.
+func alertTarget(d *schema.ResourceData, targets []string) (string, map[string]interface{}, error) {
+	var target string
+	var config map[string]interface{}
+	for _, k := range targets {
+		if c := configBlock(d.Get(k)); c != nil {
+			if config != nil {
+				return "", nil, fmt.Errorf("exactly one of %s has to be given", strings.Join(targets, ", "))
+			}
+			target, config = k, c
+		}
+	}
+	if config == nil {
+		return "", nil, fmt.Errorf("exactly one of %s has to be given", strings.Join(targets, ", "))
+	}
+	return target, config, nil
+}
+
+// expandAlertRecipients converts the configured recipients of an alert to their API representation. Rancher
+// expects the type of the notifier along with its ID, so we look it up.
+func expandAlertRecipients(c *client.Client, v interface{}) ([]client.Recipient, error) {
+	list := v.([]interface{})
+	recipients := make([]client.Recipient, 0, len(list))
+	for _, r := range list {
+		recipient := r.(map[string]interface{})
+		notifierID := recipient["notifier_id"].(string)
+		n, err := notifierByID(c, notifierID)
+		if err != nil {
+			return nil, fmt.Errorf("unable to look up notifier \"%s\": %v", notifierID, err)
+		}
+		recipients = append(recipients, client.Recipient{
+			NotifierID:   notifierID,
+			NotifierType: notifierType(n),
+			Recipient:    recipient["recipient"].(string),
+		})
+	}
+	return recipients, nil
+}
+
+// flattenAlertRecipients converts the recipients of an alert as returned by the API to their terraform
+// representation.
+func flattenAlertRecipients(recipients []client.Recipient) []interface{} {
+	list := make([]interface{}, 0, len(recipients))
+	for _, r := range recipients {
+		list = append(list, map[string]interface{}{
+			"notifier_id":   r.NotifierID,
+			"notifier_type": r.NotifierType,
+			"recipient":     r.Recipient,
+		})
+	}
+	return list
+}
+
+// alertSchema returns the schema of the attributes shared by cluster and project alerts.
+func alertSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Description: "Name of the alert",
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+		"description": {
+			Description: "Description of the alert",
+			Type:        schema.TypeString,
+			Optional:    true,
+		},
+		"severity": {
+			Description:  "Severity of the alert (\"info\", \"warning\" or \"critical\")",
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "critical",
+			ValidateFunc: validation.StringInSlice([]string{"info", "warning", "critical"}, false),
+		},
+		"initial_wait_seconds": {
+			Description: "Seconds to wait before sending the first notification",
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Default:     180,
+		},
+		"repeat_interval_seconds": {
+			Description: "Seconds to wait before repeating a notification",
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Default:     3600,
+		},
+		"recipient": {
+			Description: "Recipients of the notifications",
+			Type:        schema.TypeList,
+			Required:    true,
+			MinItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"notifier_id": {
+						Description: "ID of the notifier sending the notifications",
+						Type:        schema.TypeString,
+						Required:    true,
+					},
+					"recipient": {
+						Description: "Recipient of the notifications, defaults to the default recipient of the notifier",
+						Type:        schema.TypeString,
+						Optional:    true,
+						Computed:    true,
+					},
+					"notifier_type": {
+						Description: "Type of the notifier",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+				},
+			},
+		},
+		"state": {
+			Description: "State of the alert as reported by the Rancher API (e.g. \"active\" or \"alerting\")",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"uuid": {
+			Description: "UUID of the alert as reported by the Rancher API",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+	}
+}
+
+// alertTargetSchema returns the schema of a target of an alert, which conflicts with all other targets.
+func alertTargetSchema(description string, targets []string, target string, s map[string]*schema.Schema) *schema.Schema {
+	return &schema.Schema{
+		Description:   description,
+		Type:          schema.TypeList,
+		Optional:      true,
+		MaxItems:      1,
+		ConflictsWith: conflictingAttributes(targets, target),
+		Elem: &schema.Resource{
+			Schema: s,
+		},
+	}
+}
@@ -0,0 +1,79 @@
+package rancher2
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/rancher/types/client/management/v3"
+)
+
+func TestNotifierType(t *testing.T) {
+	tt := []struct {
+		notifier notifier
+		expected string
+	}{
+		{notifier: notifier{Notifier: client.Notifier{SlackConfig: &client.SlackConfig{}}}, expected: notifierTypeSlack},
+		{notifier: notifier{Notifier: client.Notifier{SMTPConfig: &client.SMTPConfig{}}}, expected: notifierTypeEmail},
+		{notifier: notifier{Notifier: client.Notifier{PagerdutyConfig: &client.PagerdutyConfig{}}}, expected: notifierTypePagerduty},
+		{notifier: notifier{Notifier: client.Notifier{WebhookConfig: &client.WebhookConfig{}}}, expected: notifierTypeWebhook},
+		{notifier: notifier{WechatConfig: &wechatConfig{}}, expected: notifierTypeWechat},
+	}
+	for i, td := range tt {
+		if typ := notifierType(&td.notifier); typ != td.expected {
+			t.Errorf("unexpected type of notifier %d: %s", i, typ)
+		}
+	}
+}
+
+func TestAlertTarget(t *testing.T) {
+	tt := []struct {
+		raw         map[string]interface{}
+		expected    string
+		errExpected bool
+	}{
+		{raw: map[string]interface{}{
+			"event": []interface{}{map[string]interface{}{"resource_kind": "Pod"}},
+		}, expected: "event"},
+		{raw: map[string]interface{}{
+			"node_selector": []interface{}{map[string]interface{}{"selector": map[string]interface{}{"role": "worker"}}},
+		}, expected: "node_selector"},
+		{raw: map[string]interface{}{}, errExpected: true},
+		{raw: map[string]interface{}{
+			"system_service": []interface{}{map[string]interface{}{"condition": "etcd"}},
+			"node":           []interface{}{map[string]interface{}{"node_id": "c-abcde:m-fghij"}},
+		}, errExpected: true},
+	}
+	for i, td := range tt {
+		d := schema.TestResourceDataRaw(t, resourceClusterAlert().Schema, td.raw)
+		target, _, err := alertTarget(d, clusterAlertTargets)
+		if (td.errExpected && err == nil) || (!td.errExpected && err != nil) {
+			t.Errorf("unexpected error result for alert %d: %v", i, err)
+			continue
+		}
+		if target != td.expected {
+			t.Errorf("unexpected target of alert %d: %s", i, target)
+		}
+	}
+}
+
+func TestFlattenClusterAlertTargets(t *testing.T) {
+	tt := []struct {
+		target   client.TargetNode
+		expected string
+	}{
+		{target: client.TargetNode{NodeID: "c-abcde:m-fghij", Condition: "notready"}, expected: "node"},
+		{target: client.TargetNode{Selector: map[string]string{"role": "worker"}, Condition: "cpu", CPUThreshold: 80}, expected: "node_selector"},
+	}
+	for i, td := range tt {
+		d := schema.TestResourceDataRaw(t, resourceClusterAlert().Schema, map[string]interface{}{})
+		if err := flattenClusterAlertTargets(d, &client.ClusterAlert{TargetNode: &td.target}); err != nil {
+			t.Errorf("unexpected error for alert %d: %v", i, err)
+			continue
+		}
+		for _, k := range clusterAlertTargets {
+			if configured := len(d.Get(k).([]interface{})) > 0; configured != (k == td.expected) {
+				t.Errorf("unexpected target %s of alert %d: %v", k, i, d.Get(k))
+			}
+		}
+	}
+}
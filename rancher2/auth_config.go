@@ -0,0 +1,384 @@
+package rancher2
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/rancher/norman/types"
+	"github.com/rancher/types/client/management/v3"
+)
+
+// Access modes of authentication providers, which determine who is allowed to log in.
+const (
+	authAccessModeUnrestricted = "unrestricted"
+	authAccessModeRestricted   = "restricted"
+	authAccessModeRequired     = "required"
+)
+
+// authConfigResource holds the resource metadata of the configuration of an authentication provider. The
+// provider-specific API types have a "type" attribute of their own, so they cannot simply embed types.Resource.
+type authConfigResource struct {
+	ID      string            `json:"id,omitempty" yaml:"id,omitempty"`
+	Links   map[string]string `json:"links,omitempty" yaml:"links,omitempty"`
+	Actions map[string]string `json:"actions,omitempty" yaml:"actions,omitempty"`
+}
+
+// Resource returns the resource metadata needed for updating the configuration and invoking its actions.
+func (r *authConfigResource) Resource() *types.Resource {
+	return &types.Resource{
+		ID:      r.ID,
+		Type:    client.AuthConfigType,
+		Links:   r.Links,
+		Actions: r.Actions,
+	}
+}
+
+// authConfigStatus holds the settings shared by the configurations of all authentication providers.
+type authConfigStatus struct {
+	AccessMode          string
+	AllowedPrincipalIDs []string
+	Enabled             bool
+}
+
+// authConfig is implemented by the provider-specific configuration types, which embed authConfigResource.
+type authConfig interface {
+	Resource() *types.Resource
+	Status() authConfigStatus
+}
+
+// authProvider describes how the configuration of an authentication provider is managed. All providers share
+// the same lifecycle: the configured settings are tested and the provider is enabled if the test succeeded,
+// the access control settings are applied afterwards, and the provider is disabled on destroy.
+type authProvider struct {
+	// ID of the configuration of the provider (e.g. "activedirectory").
+	ID string
+	// Name of the provider used in error messages.
+	Name string
+	// Schema of the provider-specific settings.
+	Schema map[string]*schema.Schema
+	// New returns an empty configuration the current one is read into.
+	New func() authConfig
+	// Flatten updates the terraform state with the provider-specific settings of the given configuration.
+	// Secrets are never reported by Rancher, so they must not be touched.
+	Flatten func(d *schema.ResourceData, config authConfig)
+	// Test tests the configured settings and enables the provider if the test succeeded.
+	Test func(c *client.Client, d *schema.ResourceData, r *types.Resource) error
+	// Expand converts the configured settings to the provider-specific API type. It is used instead of Test
+	// by providers that cannot be tested without an interactive login, whose settings are applied as they are.
+	Expand func(d *schema.ResourceData) interface{}
+}
+
+// authConfigByID fetches the configuration of the authentication provider with the given ID into config,
+// which has to be a provider-specific type embedding authConfigResource.
+func authConfigByID(c *client.Client, id string, config interface{}) error {
+	return c.ByID(client.AuthConfigType, id, config)
+}
+
+// authConfigLocksOut checks whether the given access control settings would prevent the given user from
+// logging in. Restricted access also admits the members of clusters and projects, but we'd rather not
+// rely on the caller being one of them.
+func authConfigLocksOut(caller *client.User, accessMode string, allowedPrincipalIDs []string) bool {
+	if accessMode == authAccessModeUnrestricted {
+		return false
+	}
+	for _, allowed := range allowedPrincipalIDs {
+		for _, principalID := range caller.PrincipalIDs {
+			if allowed == principalID {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// validateAuthConfigAccess makes sure that the user the provider is authenticated as is still allowed to log
+// in once the configured access control settings have been applied.
+func validateAuthConfigAccess(c *client.Client, d *schema.ResourceData) error {
+	accessMode := d.Get("access_mode").(string)
+	caller, err := currentUser(c)
+	if err != nil {
+		return err
+	}
+	if authConfigLocksOut(caller, accessMode, expandStringSet(d.Get("allowed_principal_ids"))) {
+		return fmt.Errorf("refusing to set access mode \"%s\": none of the allowed principals matches the current user \"%s\" (principals: %v)", accessMode, caller.Username, caller.PrincipalIDs)
+	}
+	return nil
+}
+
+// config returns the current configuration of the provider.
+func (p *authProvider) config(c *client.Client) (authConfig, error) {
+	config := p.New()
+	if err := authConfigByID(c, p.ID, config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// apply applies the configured settings. Unless only the access control settings have been changed, they
+// are tested first. The access control settings are never applied if they'd lock out the current user.
+func (p *authProvider) apply(d *schema.ResourceData, m interface{}, changed bool) error {
+	rancher := m.(Config).Rancher()
+
+	config, err := p.config(rancher)
+	if err != nil {
+		return err
+	}
+	if p.Test == nil {
+		if err := validateAuthConfigAccess(rancher, d); err != nil {
+			return err
+		}
+		if err := rancher.Update(client.AuthConfigType, config.Resource(), p.Expand(d), nil); err != nil {
+			return fmt.Errorf("unable to apply %s configuration: %v", p.Name, err)
+		}
+		return nil
+	}
+	previous := config.Status()
+	if changed {
+		if err := p.Test(rancher, d, config.Resource()); err != nil {
+			return fmt.Errorf("unable to apply %s configuration: %v", p.Name, err)
+		}
+		if config, err = p.config(rancher); err != nil {
+			return err
+		}
+	}
+	// Testing a configuration may have added the principal it has been tested with to the allowed
+	// principals, so we always overwrite them with the configured ones afterwards. Testing also links
+	// that principal to the current user, whose principal ID cannot be derived from the test username,
+	// so we check whether they're locked out only now and revert the test if they are.
+	if err := validateAuthConfigAccess(rancher, d); err != nil {
+		if !changed {
+			return err
+		}
+		if revertErr := p.revert(rancher, config, previous); revertErr != nil {
+			return fmt.Errorf("%v (reverting the tested %s configuration failed as well: %v)", err, p.Name, revertErr)
+		}
+		return err
+	}
+	return rancher.Update(client.AuthConfigType, config.Resource(), map[string]interface{}{
+		client.AuthConfigFieldAccessMode:          d.Get("access_mode").(string),
+		client.AuthConfigFieldAllowedPrincipalIDs: expandStringSet(d.Get("allowed_principal_ids")),
+	}, nil)
+}
+
+// revert restores the access control settings the given configuration had before it has been tested. If the
+// provider has not been enabled before, it is disabled again.
+func (p *authProvider) revert(c *client.Client, config authConfig, previous authConfigStatus) error {
+	if !previous.Enabled {
+		return p.disable(c, config)
+	}
+	return c.Update(client.AuthConfigType, config.Resource(), map[string]interface{}{
+		client.AuthConfigFieldAccessMode:          previous.AccessMode,
+		client.AuthConfigFieldAllowedPrincipalIDs: previous.AllowedPrincipalIDs,
+	}, nil)
+}
+
+// disable disables the provider with the given configuration. Older Rancher versions do not offer the
+// "disable" action, so we fall back to updating the configuration.
+func (p *authProvider) disable(c *client.Client, config authConfig) error {
+	r := config.Resource()
+	if _, ok := r.Actions["disable"]; ok {
+		return c.Action(client.AuthConfigType, "disable", r, nil, nil)
+	}
+	return c.Update(client.AuthConfigType, r, map[string]interface{}{
+		client.AuthConfigFieldEnabled: false,
+	}, nil)
+}
+
+// changed checks whether anything but the access control settings of the provider has been changed.
+func (p *authProvider) changed(d *schema.ResourceData) bool {
+	for k := range p.Schema {
+		if d.HasChange(k) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *authProvider) create(d *schema.ResourceData, m interface{}) error {
+	d.SetId(p.ID)
+	if err := p.apply(d, m, true); err != nil {
+		d.SetId("")
+		return err
+	}
+	return p.read(d, m)
+}
+
+func (p *authProvider) read(d *schema.ResourceData, m interface{}) error {
+	rancher := m.(Config).Rancher()
+
+	config, err := p.config(rancher)
+	if err != nil {
+		return err
+	}
+	status := config.Status()
+	if !status.Enabled {
+		// If the provider HAS BEEN DISABLED, the configuration is no longer in effect. Time to update the state...
+		d.SetId("")
+		return nil
+	}
+
+	p.Flatten(d, config)
+	d.Set("access_mode", status.AccessMode)
+	d.Set("enabled", status.Enabled)
+	return d.Set("allowed_principal_ids", flattenStringSet(status.AllowedPrincipalIDs))
+}
+
+func (p *authProvider) update(d *schema.ResourceData, m interface{}) error {
+	if err := p.apply(d, m, p.changed(d)); err != nil {
+		return err
+	}
+	return p.read(d, m)
+}
+
+func (p *authProvider) delete(d *schema.ResourceData, m interface{}) error {
+	rancher := m.(Config).Rancher()
+
+	config, err := p.config(rancher)
+	if err != nil {
+		return err
+	}
+	if !config.Status().Enabled {
+		// If the provider HAS ALREADY BEEN DISABLED, there is nothing to do for us here...
+		return nil
+	}
+	return p.disable(rancher, config)
+}
+
+func (p *authProvider) state(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if d.Id() != p.ID {
+		return nil, fmt.Errorf("the ID of the %s configuration has to be \"%s\"", p.Name, p.ID)
+	}
+	if err := p.read(d, m); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// resource returns the terraform resource managing the configuration of the provider.
+func (p *authProvider) resource() *schema.Resource {
+	s := authConfigAccessSchema()
+	for k, v := range p.Schema {
+		s[k] = v
+	}
+	return &schema.Resource{
+		Create: p.create,
+		Read:   p.read,
+		Update: p.update,
+		Delete: p.delete,
+		Importer: &schema.ResourceImporter{
+			State: p.state,
+		},
+		Schema: s,
+	}
+}
+
+// authConfigAccessSchema returns the attributes shared by the configurations of all authentication providers.
+func authConfigAccessSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"access_mode": {
+			Description: "Who is allowed to log in (\"unrestricted\", \"restricted\" or \"required\")",
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     authAccessModeUnrestricted,
+			ValidateFunc: validation.StringInSlice([]string{
+				authAccessModeUnrestricted,
+				authAccessModeRestricted,
+				authAccessModeRequired,
+			}, false),
+		},
+		"allowed_principal_ids": {
+			Description: "IDs of the principals allowed to log in if access is restricted or required",
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+		"enabled": {
+			Description: "Whether the authentication provider is enabled",
+			Type:        schema.TypeBool,
+			Computed:    true,
+		},
+	}
+}
+
+// authConfigTestSchema returns the credentials of the user the configuration of a directory-based
+// authentication provider is tested with.
+func authConfigTestSchema(provider string) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"test_username": {
+			Description: fmt.Sprintf("Username of a %s user the configuration is tested with", provider),
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+		"test_password": {
+			Description: fmt.Sprintf("Password of the %s user the configuration is tested with", provider),
+			Type:        schema.TypeString,
+			Required:    true,
+			Sensitive:   true,
+		},
+	}
+}
+
+// directoryServerSchema returns the attributes describing how to connect to the servers of a
+// directory-based authentication provider.
+func directoryServerSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"servers": {
+			Description: "Host names or IP addresses of the directory servers",
+			Type:        schema.TypeList,
+			Required:    true,
+			MinItems:    1,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+		"port": {
+			Description: "Port the directory servers listen on",
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Default:     389,
+		},
+		"tls": {
+			Description: "Whether to connect to the directory servers via TLS",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+		},
+		"certificate": {
+			Description: "PEM-encoded CA certificate used to verify the directory servers",
+			Type:        schema.TypeString,
+			Optional:    true,
+		},
+		"connection_timeout": {
+			Description: "Timeout of connections to the directory servers in milliseconds",
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Default:     5000,
+		},
+		"service_account_password": {
+			Description: "Password of the service account used to search users and groups",
+			Type:        schema.TypeString,
+			Required:    true,
+			Sensitive:   true,
+		},
+		"user_search_base": {
+			Description: "Distinguished name of the base users are searched in",
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+		"group_search_base": {
+			Description: "Distinguished name of the base groups are searched in (defaults to the user search base)",
+			Type:        schema.TypeString,
+			Optional:    true,
+		},
+	}
+}
+
+// directoryAttributeSchema returns an optional string attribute of a directory-based authentication provider
+// with the given default.
+func directoryAttributeSchema(description string, def string) *schema.Schema {
+	return &schema.Schema{
+		Description: description,
+		Type:        schema.TypeString,
+		Optional:    true,
+		Default:     def,
+	}
+}
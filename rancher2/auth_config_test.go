@@ -0,0 +1,73 @@
+package rancher2
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/rancher/types/client/management/v3"
+)
+
+func TestAuthConfigDecode(t *testing.T) {
+	// The provider-specific API types and the resource metadata both have a "type" attribute, so we make
+	// sure that everything we rely on is still decoded.
+	response := `{
+		"id": "%s",
+		"type": "%s",
+		"links": {"self": "https://rancher.example.com/v3/authConfigs/%[1]s"},
+		"actions": {"disable": "https://rancher.example.com/v3/authConfigs/%[1]s?action=disable"},
+		"accessMode": "restricted",
+		"allowedPrincipalIds": ["local://u-abcde"],
+		"enabled": true
+	}`
+	tt := []struct {
+		id         string
+		configType string
+		config     authConfig
+	}{
+		{id: activeDirectoryAuthConfigID, configType: "activeDirectoryConfig", config: &activeDirectoryConfig{}},
+		{id: openLdapAuthConfigID, configType: "openLdapConfig", config: &ldapConfig{}},
+		{id: freeIpaAuthConfigID, configType: "freeIpaConfig", config: &ldapConfig{}},
+		{id: githubAuthConfigID, configType: "githubConfig", config: &githubConfig{}},
+		{id: azureADAuthConfigID, configType: "azureADConfig", config: &azureADConfig{}},
+		{id: oktaAuthConfigID, configType: oktaConfigType, config: &samlConfig{}},
+	}
+	for _, td := range tt {
+		data := []byte(fmt.Sprintf(response, td.id, td.configType))
+		if err := json.Unmarshal(data, td.config); err != nil {
+			t.Errorf("unable to decode %s configuration: %v", td.id, err)
+			continue
+		}
+		r := td.config.Resource()
+		if r.ID != td.id || r.Links["self"] == "" || r.Actions["disable"] == "" {
+			t.Errorf("unexpected resource metadata of %s configuration: %+v", td.id, r)
+		}
+		expected := authConfigStatus{AccessMode: "restricted", AllowedPrincipalIDs: []string{"local://u-abcde"}, Enabled: true}
+		if status := td.config.Status(); !reflect.DeepEqual(status, expected) {
+			t.Errorf("unexpected status of %s configuration: %+v", td.id, status)
+		}
+	}
+}
+
+func TestAuthConfigLocksOut(t *testing.T) {
+	caller := &client.User{
+		PrincipalIDs: []string{"local://u-abcde", "activedirectory_user://CN=Doe\\, John,OU=user"},
+	}
+	tt := []struct {
+		accessMode          string
+		allowedPrincipalIDs []string
+		expected            bool
+	}{
+		{accessMode: authAccessModeUnrestricted, expected: false},
+		{accessMode: authAccessModeRestricted, expected: true},
+		{accessMode: authAccessModeRequired, allowedPrincipalIDs: []string{"local://u-fghij"}, expected: true},
+		{accessMode: authAccessModeRequired, allowedPrincipalIDs: []string{"local://u-fghij", "local://u-abcde"}, expected: false},
+		{accessMode: authAccessModeRestricted, allowedPrincipalIDs: []string{"activedirectory_user://CN=Doe\\, John,OU=user"}, expected: false},
+	}
+	for _, td := range tt {
+		if locksOut := authConfigLocksOut(caller, td.accessMode, td.allowedPrincipalIDs); locksOut != td.expected {
+			t.Errorf("unexpected result for access mode \"%s\" and %v: %v", td.accessMode, td.allowedPrincipalIDs, locksOut)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package rancher2
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+const catalogExternalIDScheme = "catalog"
+
+// catalogExternalID represents the external ID of a catalog template version, which apps are deployed from,
+// such as "catalog://?catalog=library&template=wordpress&version=2.1.10".
+type catalogExternalID struct {
+	// CatalogID is the ID of the catalog, e.g. "library" or "c-abcde:my-catalog".
+	CatalogID string
+	// Template is the name of the template within the catalog.
+	Template string
+	// Version is the version of the template.
+	Version string
+}
+
+// String formats the external ID. Cluster and project catalogs are referred to by their namespace and name,
+// and need their type to be given explicitly.
+func (e *catalogExternalID) String() string {
+	query := url.Values{}
+	scope := catalogScopeByID(e.CatalogID)
+	if scope == catalogScopeGlobal {
+		query.Set("catalog", e.CatalogID)
+	} else {
+		query.Set("catalog", strings.Replace(e.CatalogID, ":", "/", 1))
+		query.Set("type", catalogTypes[scope])
+	}
+	query.Set("template", e.Template)
+	query.Set("version", e.Version)
+	return fmt.Sprintf("%s://?%s", catalogExternalIDScheme, query.Encode())
+}
+
+// parseCatalogExternalID parses the given external ID of a catalog template version.
+func parseCatalogExternalID(id string) (*catalogExternalID, error) {
+	u, err := url.Parse(id)
+	if err != nil || u.Scheme != catalogExternalIDScheme {
+		return nil, fmt.Errorf("invalid external ID \"%s\": expected \"%s://?catalog=<catalog>&template=<template>&version=<version>\"", id, catalogExternalIDScheme)
+	}
+	query := u.Query()
+	e := &catalogExternalID{
+		CatalogID: query.Get("catalog"),
+		Template:  query.Get("template"),
+		Version:   query.Get("version"),
+	}
+	if e.CatalogID == "" || e.Template == "" || e.Version == "" {
+		return nil, fmt.Errorf("invalid external ID \"%s\": catalog, template and version are required", id)
+	}
+	if query.Get("type") != "" {
+		e.CatalogID = strings.Replace(e.CatalogID, "/", ":", 1)
+	}
+	return e, nil
+}
@@ -0,0 +1,51 @@
+package rancher2
+
+import (
+	"testing"
+)
+
+func TestCatalogExternalID(t *testing.T) {
+	tt := []struct {
+		externalID catalogExternalID
+		expected   string
+	}{
+		{
+			externalID: catalogExternalID{CatalogID: "library", Template: "wordpress", Version: "2.1.10"},
+			expected:   "catalog://?catalog=library&template=wordpress&version=2.1.10",
+		},
+		{
+			externalID: catalogExternalID{CatalogID: "c-abcde:internal-charts", Template: "node-exporter", Version: "0.1.0"},
+			expected:   "catalog://?catalog=c-abcde%2Finternal-charts&template=node-exporter&type=clusterCatalog&version=0.1.0",
+		},
+		{
+			externalID: catalogExternalID{CatalogID: "p-fghij:internal-charts", Template: "nginx-ingress", Version: "0.30.0"},
+			expected:   "catalog://?catalog=p-fghij%2Finternal-charts&template=nginx-ingress&type=projectCatalog&version=0.30.0",
+		},
+	}
+	for _, td := range tt {
+		if s := td.externalID.String(); s != td.expected {
+			t.Errorf("unexpected external ID of %+v: %s", td.externalID, s)
+		}
+		parsed, err := parseCatalogExternalID(td.expected)
+		if err != nil {
+			t.Errorf("unable to parse external ID \"%s\": %v", td.expected, err)
+			continue
+		}
+		if *parsed != td.externalID {
+			t.Errorf("unexpected result of parsing \"%s\": %+v", td.expected, parsed)
+		}
+	}
+}
+
+func TestParseCatalogExternalIDErrors(t *testing.T) {
+	for _, id := range []string{
+		"",
+		"library/wordpress:2.1.10",
+		"https://?catalog=library&template=wordpress&version=2.1.10",
+		"catalog://?catalog=library&template=wordpress",
+	} {
+		if _, err := parseCatalogExternalID(id); err == nil {
+			t.Errorf("expected an error for external ID \"%s\"", id)
+		}
+	}
+}
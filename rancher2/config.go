@@ -5,12 +5,17 @@ import (
 	"github.com/rancher/norman/clientbase"
 	rancher "github.com/rancher/types/client/management/v3"
 	"strings"
+	"sync"
 )
 
 // Config provides a way to wrap/encapsulate all the stuff that is necessary
 // to interact with the Rancher REST API.
 type Config interface {
 	Rancher() *rancher.Client
+	// TokenID returns the ID of the API token the provider is authenticated with.
+	TokenID() string
+	// Project returns a client for the resources within the project with the given ID (e.g. "c-abcde:p-fghij").
+	Project(projectID string) (*clientbase.APIBaseClient, error)
 }
 
 type config struct {
@@ -19,12 +24,42 @@ type config struct {
 	accessKey     string
 	secretKey     string
 	rancherClient *rancher.Client
+
+	projectClientsMutex sync.Mutex
+	projectClients      map[string]*clientbase.APIBaseClient
 }
 
 func (c *config) Rancher() *rancher.Client {
 	return c.rancherClient
 }
 
+func (c *config) TokenID() string {
+	// The access key of an API key pair is the name (and therefore the ID) of the underlying token.
+	return c.accessKey
+}
+
+func (c *config) Project(projectID string) (*clientbase.APIBaseClient, error) {
+	c.projectClientsMutex.Lock()
+	defer c.projectClientsMutex.Unlock()
+
+	// Creating a client fetches all schemas of the project API, so we only do that once per project. We
+	// use the generic client, as the generated one pulls in half of Kubernetes.
+	if projectClient, exists := c.projectClients[projectID]; exists {
+		return projectClient, nil
+	}
+	projectClient, err := clientbase.NewAPIClient(&clientbase.ClientOpts{
+		URL:       fmt.Sprintf("%s/projects/%s", c.url, projectID),
+		AccessKey: c.accessKey,
+		SecretKey: c.secretKey,
+		CACerts:   c.cacert,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to create Rancher client for project \"%s\": %v", projectID, err)
+	}
+	c.projectClients[projectID] = &projectClient
+	return &projectClient, nil
+}
+
 // NewConfig creates a new configuration structure to be used provider-internally.
 func NewConfig(url string, accessKey string, secretKey string, cacert string) (Config, error) {
 
@@ -49,11 +84,12 @@ func NewConfig(url string, accessKey string, secretKey string, cacert string) (C
 	}
 
 	return &config{
-		url:           url,
-		cacert:        cacert,
-		accessKey:     accessKey,
-		secretKey:     secretKey,
-		rancherClient: rancherClient,
+		url:            url,
+		cacert:         cacert,
+		accessKey:      accessKey,
+		secretKey:      secretKey,
+		rancherClient:  rancherClient,
+		projectClients: map[string]*clientbase.APIBaseClient{},
 	}, nil
 
 }
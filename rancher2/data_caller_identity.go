@@ -5,34 +5,140 @@ import (
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/rancher/norman/clientbase"
 	"github.com/rancher/norman/types"
+	"github.com/rancher/types/client/management/v3"
 )
 
-func dataCallerIdentityRead(d *schema.ResourceData, m interface{}) error {
-	rancher := m.(Config).Rancher()
+// adminGlobalRoleID is the ID of the built-in global role that grants full administrative access.
+const adminGlobalRoleID = "admin"
 
-	filters := map[string]interface{}{
-		"me":      true,
-		"enabled": true,
-	}
-
-	users, err := rancher.User.List(&types.ListOpts{
-		Filters: filters,
+// currentUser returns the Rancher user the provider is authenticated as.
+func currentUser(c *client.Client) (*client.User, error) {
+	users, err := c.User.List(&types.ListOpts{
+		Filters: map[string]interface{}{
+			"me":      true,
+			"enabled": true,
+		},
 	})
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if len(users.Data) <= 0 {
-		return fmt.Errorf("could not determine current Rancher user")
+		return nil, fmt.Errorf("could not determine current Rancher user")
 	}
 	if len(users.Data) > 1 {
-		return fmt.Errorf("more than one current Rancher user found (this should never happen!)")
+		return nil, fmt.Errorf("more than one current Rancher user found (this should never happen!)")
+	}
+	return &users.Data[0], nil
+}
+
+// callerClusterMemberships returns the cluster role template bindings of the given user.
+func callerClusterMemberships(c *client.Client, userID string) ([]interface{}, error) {
+	collection, err := c.ClusterRoleTemplateBinding.List(&types.ListOpts{
+		Filters: map[string]interface{}{
+			"userId": userID,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	memberships := make([]interface{}, 0, len(collection.Data))
+	for collection != nil {
+		for _, binding := range collection.Data {
+			if binding.UserID != userID {
+				continue
+			}
+			memberships = append(memberships, map[string]interface{}{
+				"cluster_id":       binding.ClusterID,
+				"role_template_id": binding.RoleTemplateID,
+			})
+		}
+		if collection, err = collection.Next(); err != nil {
+			return nil, err
+		}
+	}
+	return memberships, nil
+}
+
+// callerProjectMemberships returns the project role template bindings of the given user.
+func callerProjectMemberships(c *client.Client, userID string) ([]interface{}, error) {
+	collection, err := c.ProjectRoleTemplateBinding.List(&types.ListOpts{
+		Filters: map[string]interface{}{
+			"userId": userID,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	memberships := make([]interface{}, 0, len(collection.Data))
+	for collection != nil {
+		for _, binding := range collection.Data {
+			if binding.UserID != userID {
+				continue
+			}
+			memberships = append(memberships, map[string]interface{}{
+				"project_id":       binding.ProjectID,
+				"role_template_id": binding.RoleTemplateID,
+			})
+		}
+		if collection, err = collection.Next(); err != nil {
+			return nil, err
+		}
+	}
+	return memberships, nil
+}
+
+func dataCallerIdentityRead(d *schema.ResourceData, m interface{}) error {
+	rancher := m.(Config).Rancher()
+
+	user, err := currentUser(rancher)
+	if err != nil {
+		return err
 	}
 
-	user := users.Data[0] // We simply use the first token that matches the search criteria.
 	d.SetId(user.ID)
+	d.Set("user_id", user.ID)
+	d.Set("username", user.Username)
 	d.Set("name", user.Name)
 	d.Set("description", user.Description)
 	d.Set("uuid", user.UUID)
+	if err := d.Set("principal_ids", user.PrincipalIDs); err != nil {
+		return err
+	}
+
+	globalRoleIDs, err := userGlobalRoleIDs(rancher, user.ID)
+	if err != nil {
+		return err
+	}
+	if err := d.Set("global_role_ids", globalRoleIDs); err != nil {
+		return err
+	}
+	d.Set("is_admin", flattenStringSet(globalRoleIDs).Contains(adminGlobalRoleID))
+
+	clusterMemberships, err := callerClusterMemberships(rancher, user.ID)
+	if err != nil {
+		return err
+	}
+	if err := d.Set("cluster_memberships", clusterMemberships); err != nil {
+		return err
+	}
+	projectMemberships, err := callerProjectMemberships(rancher, user.ID)
+	if err != nil {
+		return err
+	}
+	if err := d.Set("project_memberships", projectMemberships); err != nil {
+		return err
+	}
+
+	if tokenID := m.(Config).TokenID(); tokenID != "" {
+		token, err := tokenByID(rancher, tokenID)
+		if err != nil {
+			return fmt.Errorf("unable to read token \"%s\" of current Rancher user: %v", tokenID, err)
+		}
+		d.Set("token_id", token.ID)
+		d.Set("token_expires_at", token.ExpiresAt)
+		d.Set("token_expired", token.Expired)
+		d.Set("token_cluster_id", token.ClusterID)
+	}
 	return nil
 }
 
@@ -55,6 +161,16 @@ func dataCallerIdentity() *schema.Resource {
 		Read:   dataCallerIdentityRead,
 		Exists: dataCallerIdentityExists,
 		Schema: map[string]*schema.Schema{
+			"user_id": {
+				Description: "ID of the current Rancher user",
+				Computed:    true,
+				Type:        schema.TypeString,
+			},
+			"username": {
+				Description: "Username (login) of the current Rancher user",
+				Computed:    true,
+				Type:        schema.TypeString,
+			},
 			"name": {
 				Computed: true,
 				Type:     schema.TypeString,
@@ -67,6 +183,77 @@ func dataCallerIdentity() *schema.Resource {
 				Computed: true,
 				Type:     schema.TypeString,
 			},
+			"principal_ids": {
+				Description: "IDs of all principals associated with the current Rancher user",
+				Computed:    true,
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"global_role_ids": {
+				Description: "IDs of the global roles bound to the current Rancher user",
+				Computed:    true,
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"is_admin": {
+				Description: "Whether the current Rancher user has been granted the admin global role",
+				Computed:    true,
+				Type:        schema.TypeBool,
+			},
+			"cluster_memberships": {
+				Description: "Role templates bound to the current Rancher user in clusters",
+				Computed:    true,
+				Type:        schema.TypeList,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"cluster_id": {
+							Computed: true,
+							Type:     schema.TypeString,
+						},
+						"role_template_id": {
+							Computed: true,
+							Type:     schema.TypeString,
+						},
+					},
+				},
+			},
+			"project_memberships": {
+				Description: "Role templates bound to the current Rancher user in projects",
+				Computed:    true,
+				Type:        schema.TypeList,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"project_id": {
+							Computed: true,
+							Type:     schema.TypeString,
+						},
+						"role_template_id": {
+							Computed: true,
+							Type:     schema.TypeString,
+						},
+					},
+				},
+			},
+			"token_id": {
+				Description: "ID of the API token the provider is authenticated with",
+				Computed:    true,
+				Type:        schema.TypeString,
+			},
+			"token_expires_at": {
+				Description: "Expiry date of the API token the provider is authenticated with (empty if it never expires)",
+				Computed:    true,
+				Type:        schema.TypeString,
+			},
+			"token_expired": {
+				Description: "Whether the API token the provider is authenticated with has expired",
+				Computed:    true,
+				Type:        schema.TypeBool,
+			},
+			"token_cluster_id": {
+				Description: "ID of the cluster the API token the provider is authenticated with is scoped to (empty if not scoped)",
+				Computed:    true,
+				Type:        schema.TypeString,
+			},
 		},
 	}
 }
@@ -0,0 +1,284 @@
+package rancher2
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/rancher/norman/types"
+	"github.com/rancher/types/client/management/v3"
+)
+
+// Attributes of templates referring to the catalog they belong to, for each scope of catalogs.
+var catalogTemplateCatalogFields = map[string]string{
+	catalogScopeGlobal:  "catalogId",
+	catalogScopeCluster: "clusterCatalogId",
+	catalogScopeProject: "projectCatalogId",
+}
+
+// catalogTemplate extends the generated API type by the attributes referring to cluster and project
+// catalogs, which are not known to the version of the Rancher client library we are using.
+type catalogTemplate struct {
+	client.Template
+	ClusterCatalogID string `json:"clusterCatalogId,omitempty" yaml:"clusterCatalogId,omitempty"`
+	ProjectCatalogID string `json:"projectCatalogId,omitempty" yaml:"projectCatalogId,omitempty"`
+}
+
+// catalogTemplateCollection is the collection of the extended template type.
+type catalogTemplateCollection struct {
+	types.Collection
+	Data []catalogTemplate `json:"data,omitempty"`
+}
+
+// catalogTemplateByName returns the template with the given name (i.e. its folder within the catalog) from
+// the catalog with the given ID.
+func catalogTemplateByName(c *client.Client, catalogID string, name string) (*catalogTemplate, error) {
+	collection := &catalogTemplateCollection{}
+	if err := c.List(client.TemplateType, &types.ListOpts{
+		Filters: map[string]interface{}{
+			catalogTemplateCatalogFields[catalogScopeByID(catalogID)]: catalogID,
+		},
+	}, collection); err != nil {
+		return nil, err
+	}
+	for {
+		for _, t := range collection.Data {
+			if t.FolderName == name && (t.CatalogID == catalogID || t.ClusterCatalogID == catalogID || t.ProjectCatalogID == catalogID) {
+				return &t, nil
+			}
+		}
+		if collection.Pagination == nil || collection.Pagination.Next == "" {
+			break
+		}
+		next := &catalogTemplateCollection{}
+		if err := c.Ops.DoNext(collection.Pagination.Next, next); err != nil {
+			return nil, err
+		}
+		collection = next
+	}
+	return nil, fmt.Errorf("template \"%s\" not found in catalog \"%s\"", name, catalogID)
+}
+
+// latestTemplateVersion returns the latest of the given versions that satisfies the given constraint (e.g.
+// "~> 1.2"). Pre-releases are only considered if the constraint refers to them explicitly.
+func latestTemplateVersion(versions []string, constraint string) (string, error) {
+	var constraints version.Constraints
+	if constraint != "" {
+		var err error
+		if constraints, err = version.NewConstraint(constraint); err != nil {
+			return "", fmt.Errorf("invalid version constraint \"%s\": %v", constraint, err)
+		}
+	}
+
+	var latest *version.Version
+	for _, v := range versions {
+		parsed, err := version.NewVersion(v)
+		if err != nil {
+			// Charts are not required to follow semantic versioning, so we simply skip such versions.
+			continue
+		}
+		if constraints == nil && parsed.Prerelease() != "" {
+			continue
+		}
+		if constraints != nil && !constraints.Check(parsed) {
+			continue
+		}
+		if latest == nil || parsed.GreaterThan(latest) {
+			latest = parsed
+		}
+	}
+	if latest == nil {
+		if constraint != "" {
+			return "", fmt.Errorf("no version satisfies the constraint \"%s\"", constraint)
+		}
+		return "", fmt.Errorf("no version found")
+	}
+	return latest.Original(), nil
+}
+
+// flattenQuestions converts the questions of a template version to their terraform representation and
+// collects the default values of all questions and their subquestions.
+func flattenQuestions(questions []client.Question) ([]interface{}, map[string]interface{}) {
+	flattened := make([]interface{}, 0, len(questions))
+	defaults := map[string]interface{}{}
+	for _, q := range questions {
+		flattened = append(flattened, map[string]interface{}{
+			"variable":    q.Variable,
+			"label":       q.Label,
+			"description": q.Description,
+			"type":        q.Type,
+			"default":     q.Default,
+			"required":    q.Required,
+			"group":       q.Group,
+			"options":     q.Options,
+		})
+		if q.Default != "" {
+			defaults[q.Variable] = q.Default
+		}
+		for _, sq := range q.Subquestions {
+			if sq.Default != "" {
+				defaults[sq.Variable] = sq.Default
+			}
+		}
+	}
+	return flattened, defaults
+}
+
+func dataCatalogTemplateRead(d *schema.ResourceData, m interface{}) error {
+	rancher := m.(Config).Rancher()
+
+	catalogID := d.Get("catalog_id").(string)
+	name := d.Get("name").(string)
+
+	template, err := catalogTemplateByName(rancher, catalogID, name)
+	if err != nil {
+		return err
+	}
+
+	versions := make([]string, 0, len(template.Versions))
+	for _, v := range template.Versions {
+		versions = append(versions, v.Version)
+	}
+	selected, err := latestTemplateVersion(versions, d.Get("version_constraint").(string))
+	if err != nil {
+		return fmt.Errorf("unable to select version of template \"%s\": %v", name, err)
+	}
+
+	templateVersion := &client.TemplateVersion{}
+	if link, ok := template.VersionLinks[selected]; ok {
+		err = rancher.Ops.DoGet(link, nil, templateVersion)
+	} else {
+		templateVersion, err = rancher.TemplateVersion.ByID(fmt.Sprintf("%s-%s", template.ID, selected))
+	}
+	if err != nil {
+		return fmt.Errorf("unable to fetch version \"%s\" of template \"%s\": %v", selected, name, err)
+	}
+
+	// The external ID is what apps refer to, so we make sure it is always there.
+	externalID := templateVersion.ExternalID
+	if externalID == "" {
+		externalID = (&catalogExternalID{CatalogID: catalogID, Template: name, Version: selected}).String()
+	}
+
+	sort.Slice(versions, func(i, j int) bool {
+		vi, erri := version.NewVersion(versions[i])
+		vj, errj := version.NewVersion(versions[j])
+		if erri != nil || errj != nil {
+			return versions[i] > versions[j]
+		}
+		return vi.GreaterThan(vj)
+	})
+	questions, defaults := flattenQuestions(templateVersion.Questions)
+
+	d.SetId(templateVersion.ID)
+	d.Set("template_id", template.ID)
+	d.Set("description", template.Description)
+	d.Set("default_version", template.DefaultVersion)
+	d.Set("versions", versions)
+	d.Set("version", selected)
+	d.Set("external_id", externalID)
+	d.Set("default_values", defaults)
+	return d.Set("questions", questions)
+}
+
+func dataCatalogTemplate() *schema.Resource {
+	return &schema.Resource{
+		Read: dataCatalogTemplateRead,
+		Schema: map[string]*schema.Schema{
+			"catalog_id": {
+				Description: "ID of the catalog the template belongs to (e.g. \"library\" or \"c-abcde:my-catalog\")",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"name": {
+				Description: "Name of the template (i.e. its folder within the catalog)",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"version_constraint": {
+				Description: "Constraint the selected version has to satisfy (e.g. \"~> 1.2\" or \">= 1.0, < 2.0\")",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"template_id": {
+				Description: "ID of the template",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"description": {
+				Description: "Description of the template",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"default_version": {
+				Description: "Default version of the template as declared by the catalog",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"versions": {
+				Description: "All versions of the template, latest first",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"version": {
+				Description: "Latest version that satisfies the version constraint",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"external_id": {
+				Description: "External ID of the selected version apps refer to",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"default_values": {
+				Description: "Default answers to the questions of the selected version",
+				Type:        schema.TypeMap,
+				Computed:    true,
+			},
+			"questions": {
+				Description: "Questions of the selected version",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"variable": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"label": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"default": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"required": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"group": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"options": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,36 @@
+package rancher2
+
+import (
+	"testing"
+)
+
+func TestLatestTemplateVersion(t *testing.T) {
+	versions := []string{"0.9.0", "1.0.0", "1.2.3", "1.10.0", "2.0.0-rc1", "latest"}
+	tt := []struct {
+		constraint  string
+		expected    string
+		errExpected bool
+	}{
+		{constraint: "", expected: "1.10.0"},
+		{constraint: "~> 1.2.0", expected: "1.2.3"},
+		{constraint: ">= 1.0, < 1.5", expected: "1.2.3"},
+		{constraint: "< 1.0", expected: "0.9.0"},
+		{constraint: "2.0.0-rc1", expected: "2.0.0-rc1"},
+		{constraint: ">= 3.0", errExpected: true},
+		{constraint: "not a constraint", errExpected: true},
+	}
+	for _, td := range tt {
+		v, err := latestTemplateVersion(versions, td.constraint)
+		if td.errExpected {
+			if err == nil {
+				t.Errorf("expected error for constraint \"%s\", got version %s", td.constraint, v)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error for constraint \"%s\": %v", td.constraint, err)
+		} else if v != td.expected {
+			t.Errorf("unexpected version for constraint \"%s\": %s", td.constraint, v)
+		}
+	}
+}
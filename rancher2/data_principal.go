@@ -0,0 +1,125 @@
+package rancher2
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/rancher/types/client/management/v3"
+)
+
+// searchPrincipals searches the principals of all enabled authentication providers by name and type.
+func searchPrincipals(c *client.Client, name string, principalType string) ([]client.Principal, error) {
+	// The search action is only available on the principal collection, so we have to fetch it first.
+	collection, err := c.Principal.List(nil)
+	if err != nil {
+		return nil, err
+	}
+	result, err := c.Principal.CollectionActionSearch(collection, &client.SearchPrincipalsInput{
+		Name:          name,
+		PrincipalType: principalType,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.Data, nil
+}
+
+// principalByName returns the principal whose name or login name matches the given one exactly. Rancher
+// searches principals by prefix, but we never fall back to a non-exact match, as binding roles to a principal
+// that has not been named would grant privileges to the wrong users.
+func principalByName(principals []client.Principal, name string, provider string) (*client.Principal, error) {
+	candidates := make([]client.Principal, 0, len(principals))
+	for _, principal := range principals {
+		if provider == "" || principal.Provider == provider {
+			candidates = append(candidates, principal)
+		}
+	}
+	exact := make([]client.Principal, 0, len(candidates))
+	for _, principal := range candidates {
+		if strings.EqualFold(principal.Name, name) || strings.EqualFold(principal.LoginName, name) {
+			exact = append(exact, principal)
+		}
+	}
+
+	switch len(exact) {
+	case 0:
+		if len(candidates) == 0 {
+			return nil, fmt.Errorf("principal with name \"%s\" not found", name)
+		}
+		names := make([]string, 0, len(candidates))
+		for _, principal := range candidates {
+			names = append(names, fmt.Sprintf("\"%s\" (ID: \"%s\")", principal.Name, principal.ID))
+		}
+		return nil, fmt.Errorf("no principal with name \"%s\" found, did you mean one of: %s", name, strings.Join(names, ", "))
+	case 1:
+		return &exact[0], nil
+	default:
+		return nil, fmt.Errorf("more than one principal with specified name (\"%s\") found: %d", name, len(exact))
+	}
+}
+
+func dataPrincipalRead(d *schema.ResourceData, m interface{}) error {
+	rancher := m.(Config).Rancher()
+
+	name := d.Get("name").(string)
+
+	principals, err := searchPrincipals(rancher, name, d.Get("type").(string))
+	if err != nil {
+		return err
+	}
+	principal, err := principalByName(principals, name, d.Get("auth_provider").(string))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(principal.ID)
+	d.Set("principal_id", principal.ID)
+	d.Set("type", principal.PrincipalType)
+	d.Set("display_name", principal.Name)
+	d.Set("login_name", principal.LoginName)
+	d.Set("auth_provider", principal.Provider)
+	return nil
+}
+
+func dataPrincipal() *schema.Resource {
+	return &schema.Resource{
+		Read: dataPrincipalRead,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "Name or login name of the principal to look up",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"type": {
+				Description:  "Type of the principal to look up (\"user\" or \"group\")",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"user", "group"}, false),
+			},
+			"auth_provider": {
+				Description: "Authentication provider of the principal (e.g. \"activedirectory\" or \"github\")",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"principal_id": {
+				Description: "Canonical ID of the principal (e.g. \"activedirectory_group://CN=...\")",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"display_name": {
+				Description: "Display name of the principal",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"login_name": {
+				Description: "Login name of the principal",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
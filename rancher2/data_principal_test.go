@@ -0,0 +1,49 @@
+package rancher2
+
+import (
+	"testing"
+
+	"github.com/rancher/norman/types"
+	"github.com/rancher/types/client/management/v3"
+)
+
+func TestPrincipalByName(t *testing.T) {
+	principal := func(id string, name string, loginName string, provider string) client.Principal {
+		return client.Principal{
+			Resource:  types.Resource{ID: id},
+			Name:      name,
+			LoginName: loginName,
+			Provider:  provider,
+		}
+	}
+	principals := []client.Principal{
+		principal("activedirectory_group://CN=Developers,OU=Groups", "Developers", "developers", "activedirectory"),
+		principal("activedirectory_group://CN=Developers Team,OU=Groups", "Developers Team", "developers-team", "activedirectory"),
+		principal("github_org://1234", "developers", "developers", "github"),
+	}
+	tt := []struct {
+		name        string
+		provider    string
+		expectedID  string
+		errExpected bool
+	}{
+		{name: "Developers Team", expectedID: "activedirectory_group://CN=Developers Team,OU=Groups"},
+		{name: "developers-team", expectedID: "activedirectory_group://CN=Developers Team,OU=Groups"},
+		{name: "developers", provider: "github", expectedID: "github_org://1234"},
+		{name: "developers", provider: "activedirectory", expectedID: "activedirectory_group://CN=Developers,OU=Groups"},
+		{name: "developers", errExpected: true},
+		{name: "Devel", provider: "activedirectory", errExpected: true},
+		{name: "Devel", provider: "github", errExpected: true},
+		{name: "Devel", provider: "openldap", errExpected: true},
+	}
+	for _, td := range tt {
+		p, err := principalByName(principals, td.name, td.provider)
+		if (td.errExpected && err == nil) || (!td.errExpected && err != nil) {
+			t.Errorf("unexpected error result for \"%s\": %v", td.name, err)
+			continue
+		}
+		if !td.errExpected && p.ID != td.expectedID {
+			t.Errorf("unexpected principal for \"%s\": expected \"%s\", got \"%s\"", td.name, td.expectedID, p.ID)
+		}
+	}
+}
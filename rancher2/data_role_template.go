@@ -0,0 +1,116 @@
+package rancher2
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+func dataRoleTemplateRead(d *schema.ResourceData, m interface{}) error {
+	rancher := m.(Config).Rancher()
+
+	name := d.Get("name").(string)
+	roleTemplate, err := roleTemplateByName(rancher, name, d.Get("context").(string))
+	if err != nil {
+		return err
+	}
+	if roleTemplate == nil {
+		return fmt.Errorf("role template with name \"%s\" not found", name)
+	}
+
+	d.SetId(roleTemplate.ID)
+	d.Set("default_role", (roleTemplate.Context == roleTemplateContextCluster && roleTemplate.ClusterCreatorDefault) ||
+		(roleTemplate.Context == roleTemplateContextProject && roleTemplate.ProjectCreatorDefault))
+	d.Set("cluster_creator_default", roleTemplate.ClusterCreatorDefault)
+	d.Set("project_creator_default", roleTemplate.ProjectCreatorDefault)
+	return setRoleTemplate(d, roleTemplate)
+}
+
+func dataRoleTemplate() *schema.Resource {
+	computedStringSet := func(description string) *schema.Schema {
+		return &schema.Schema{
+			Description: description,
+			Type:        schema.TypeSet,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		}
+	}
+	return &schema.Resource{
+		Read: dataRoleTemplateRead,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "Name of the role template (e.g. \"Project Member\")",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"context": {
+				Description:  "Context the role template can be bound in (\"cluster\" or \"project\")",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{roleTemplateContextCluster, roleTemplateContextProject}, false),
+			},
+			"description": {
+				Description: "Description of the role template",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"rules": {
+				Description: "Kubernetes RBAC policy rules granted by the role",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"api_groups":        computedStringSet("API groups of the resources the rule applies to"),
+						"resources":         computedStringSet("Resources the rule applies to"),
+						"verbs":             computedStringSet("Verbs the rule allows"),
+						"resource_names":    computedStringSet("Names of the resources the rule is restricted to"),
+						"non_resource_urls": computedStringSet("Non-resource URLs the rule applies to"),
+					},
+				},
+			},
+			"role_template_ids": computedStringSet("IDs of the role templates whose rules are inherited"),
+			"locked": {
+				Description: "Whether the role template may no longer be used for new bindings",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"default_role": {
+				Description: "Whether the role template is granted to creators of clusters or projects (depending on its context)",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"cluster_creator_default": {
+				Description: "Whether the role template is granted to creators of clusters",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"project_creator_default": {
+				Description: "Whether the role template is granted to creators of projects",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"builtin": {
+				Description: "Whether the role template is built into Rancher",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"hidden": {
+				Description: "Whether the role template is hidden in the UI",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"external": {
+				Description: "Whether the rules of the role template are managed outside of Rancher",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"uuid": {
+				Description: "UUID of the role template as reported by the Rancher API",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
@@ -0,0 +1,42 @@
+package rancher2
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSettingRead(d *schema.ResourceData, m interface{}) error {
+	rancher := m.(Config).Rancher()
+
+	setting, err := rancher.Setting.ByID(d.Get("name").(string))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(setting.ID)
+	setSetting(d, setting)
+	d.Set("read_only", settingReadOnly(setting))
+	return nil
+}
+
+func dataSetting() *schema.Resource {
+	s := settingAttributesSchema()
+	s["name"] = &schema.Schema{
+		Description: "Name of the setting (e.g. \"server-version\")",
+		Type:        schema.TypeString,
+		Required:    true,
+	}
+	s["value"] = &schema.Schema{
+		Description: "Value of the setting",
+		Type:        schema.TypeString,
+		Computed:    true,
+	}
+	s["read_only"] = &schema.Schema{
+		Description: "Whether the setting is read-only",
+		Type:        schema.TypeBool,
+		Computed:    true,
+	}
+	return &schema.Resource{
+		Read:   dataSettingRead,
+		Schema: s,
+	}
+}
@@ -5,37 +5,120 @@ import (
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/rancher/norman/clientbase"
 	"github.com/rancher/norman/types"
+	"github.com/rancher/types/client/management/v3"
+	"sort"
+	"time"
 )
 
-func dataTokenRead(d *schema.ResourceData, m interface{}) error {
-	rancher := m.(Config).Rancher()
-
+// tokensBySearchCriteria returns all tokens that match the search criteria given to a token data source.
+func tokensBySearchCriteria(c *client.Client, d *schema.ResourceData) ([]token, error) {
 	userID := d.Get("user_id").(string)
+	name := d.Get("name").(string)
+	description := d.Get("description").(string)
+	clusterID := d.Get("cluster_id").(string)
+	expired, expiredSet := d.GetOkExists("expired")
+	isDerived, isDerivedSet := d.GetOkExists("is_derived")
 
-	filters := map[string]interface{}{
-		"user_id": userID,
+	filters := map[string]interface{}{}
+	if userID != "" {
+		filters["userId"] = userID
 	}
-	if expired, expiredSet := d.GetOkExists("expired"); expiredSet {
+	if name != "" {
+		filters["name"] = name
+	}
+	if description != "" {
+		filters["description"] = description
+	}
+	if expiredSet {
 		filters["expired"] = expired
 	}
-	tokens, err := rancher.Token.List(&types.ListOpts{
-		Filters: filters,
+	if isDerivedSet {
+		filters["isDerived"] = isDerived
+	}
+
+	collection := &tokenCollection{}
+	if err := c.List(client.TokenType, &types.ListOpts{Filters: filters}, collection); err != nil {
+		return nil, err
+	}
+
+	// Rancher silently ignores filters it does not know, so we have to check all of them on our own.
+	tokens := make([]token, 0, len(collection.Data))
+	for {
+		for _, t := range collection.Data {
+			if (userID != "" && t.UserID != userID) ||
+				(name != "" && t.Name != name) ||
+				(description != "" && t.Description != description) ||
+				(clusterID != "" && t.ClusterID != clusterID) ||
+				(expiredSet && t.Expired != expired.(bool)) ||
+				(isDerivedSet && t.IsDerived != isDerived.(bool)) {
+				continue
+			}
+			tokens = append(tokens, t)
+		}
+		if collection.Pagination == nil || collection.Pagination.Next == "" {
+			break
+		}
+		next := &tokenCollection{}
+		if err := c.Ops.DoNext(collection.Pagination.Next, next); err != nil {
+			return nil, err
+		}
+		collection = next
+	}
+	return tokens, nil
+}
+
+// sortTokensByCreation sorts the given tokens so that the most recently created token comes first.
+func sortTokensByCreation(tokens []token) {
+	created := func(t *token) time.Time {
+		c, _ := time.Parse(time.RFC3339, t.Created)
+		return c
+	}
+	sort.SliceStable(tokens, func(i, j int) bool {
+		return created(&tokens[i]).After(created(&tokens[j]))
 	})
+}
+
+// flattenToken converts the given token into the representation used by the token data sources.
+func flattenToken(t *token) map[string]interface{} {
+	return map[string]interface{}{
+		"token_id":     t.ID,
+		"user_id":      t.UserID,
+		"name":         t.Name,
+		"description":  t.Description,
+		"cluster_id":   t.ClusterID,
+		"is_derived":   t.IsDerived,
+		"expired":      t.Expired,
+		"expires_at":   t.ExpiresAt,
+		"created":      t.Created,
+		"last_used_at": t.LastUsedAt,
+		"uuid":         t.UUID,
+	}
+}
+
+func dataTokenRead(d *schema.ResourceData, m interface{}) error {
+	rancher := m.(Config).Rancher()
+
+	tokens, err := tokensBySearchCriteria(rancher, d)
 	if err != nil {
 		return err
 	}
-	if len(tokens.Data) <= 0 {
-		return fmt.Errorf("no tokens for user %s have been found that match the search criteria", userID)
+	if len(tokens) <= 0 {
+		return fmt.Errorf("no tokens have been found that match the search criteria")
+	}
+	if len(tokens) > 1 {
+		if !d.Get("most_recent").(bool) {
+			return fmt.Errorf("more than one token matches the search criteria: %d (consider setting most_recent)", len(tokens))
+		}
+		sortTokensByCreation(tokens)
 	}
 
-	token := tokens.Data[0] // We simply use the first token that matches the search criteria.
+	token := tokens[0]
 	d.SetId(token.ID)
-	d.Set("user_id", token.UserID)
-	d.Set("is_derived", token.IsDerived)
-	d.Set("expired", token.Expired)
-	d.Set("name", token.Name)
-	d.Set("description", token.Description)
-	d.Set("uuid", token.UUID)
+	for k, v := range flattenToken(&token) {
+		if err := d.Set(k, v); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -53,35 +136,86 @@ func dataTokenExists(d *schema.ResourceData, m interface{}) (bool, error) {
 	return token != nil, err
 }
 
+// tokenSearchCriteriaSchema returns the schema of the attributes tokens can be searched by.
+func tokenSearchCriteriaSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"user_id": {
+			Optional: true,
+			Computed: true,
+			Type:     schema.TypeString,
+		},
+		"name": {
+			Optional: true,
+			Computed: true,
+			Type:     schema.TypeString,
+		},
+		"description": {
+			Optional: true,
+			Computed: true,
+			Type:     schema.TypeString,
+		},
+		"cluster_id": {
+			Description: "ID of the cluster the token is scoped to",
+			Optional:    true,
+			Computed:    true,
+			Type:        schema.TypeString,
+		},
+		"is_derived": {
+			Description: "Whether the token has been derived from a login token",
+			Optional:    true,
+			Computed:    true,
+			Type:        schema.TypeBool,
+		},
+		"expired": {
+			Optional: true,
+			Computed: true,
+			Type:     schema.TypeBool,
+		},
+	}
+}
+
+// tokenAttributesSchema returns the schema of the attributes the token data sources report about tokens.
+func tokenAttributesSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"expires_at": {
+			Description: "Expiry date of the token (empty if the token never expires)",
+			Computed:    true,
+			Type:        schema.TypeString,
+		},
+		"created": {
+			Description: "Creation date of the token",
+			Computed:    true,
+			Type:        schema.TypeString,
+		},
+		"last_used_at": {
+			Description: "Date the token has been used for the last time",
+			Computed:    true,
+			Type:        schema.TypeString,
+		},
+		"uuid": {
+			Computed: true,
+			Type:     schema.TypeString,
+		},
+	}
+}
+
 func dataToken() *schema.Resource {
+	s := tokenSearchCriteriaSchema()
+	for k, v := range tokenAttributesSchema() {
+		s[k] = v
+	}
+	s["token_id"] = &schema.Schema{
+		Computed: true,
+		Type:     schema.TypeString,
+	}
+	s["most_recent"] = &schema.Schema{
+		Description: "Whether to use the most recently created token if more than one token matches",
+		Optional:    true,
+		Type:        schema.TypeBool,
+	}
 	return &schema.Resource{
 		Read:   dataTokenRead,
 		Exists: dataTokenExists,
-		Schema: map[string]*schema.Schema{
-			"is_derived": {
-				Computed: true,
-				Type:     schema.TypeBool,
-			},
-			"user_id": {
-				Required: true,
-				Type:     schema.TypeString,
-			},
-			"expired": {
-				Optional: true,
-				Type:     schema.TypeBool,
-			},
-			"name": {
-				Optional: true,
-				Type:     schema.TypeString,
-			},
-			"description": {
-				Optional: true,
-				Type:     schema.TypeString,
-			},
-			"uuid": {
-				Computed: true,
-				Type:     schema.TypeString,
-			},
-		},
+		Schema: s,
 	}
 }
@@ -0,0 +1,74 @@
+package rancher2
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataTokensRead(d *schema.ResourceData, m interface{}) error {
+	rancher := m.(Config).Rancher()
+
+	tokens, err := tokensBySearchCriteria(rancher, d)
+	if err != nil {
+		return err
+	}
+	sortTokensByCreation(tokens)
+
+	ids := make([]string, 0, len(tokens))
+	flattened := make([]interface{}, 0, len(tokens))
+	for i := range tokens {
+		ids = append(ids, tokens[i].ID)
+		flattened = append(flattened, flattenToken(&tokens[i]))
+	}
+
+	// The ID of the data source is derived from the IDs of the tokens found.
+	sortedIDs := append([]string{}, ids...)
+	sort.Strings(sortedIDs)
+	d.SetId(fmt.Sprintf("%x", sha1.Sum([]byte(strings.Join(sortedIDs, ",")))))
+	if err := d.Set("ids", ids); err != nil {
+		return err
+	}
+	return d.Set("tokens", flattened)
+}
+
+func dataTokens() *schema.Resource {
+	s := tokenSearchCriteriaSchema()
+	// The search criteria cannot be computed, as there are potentially many tokens found.
+	for _, v := range s {
+		v.Computed = false
+	}
+	t := tokenSearchCriteriaSchema()
+	for k, v := range tokenAttributesSchema() {
+		t[k] = v
+	}
+	for _, v := range t {
+		v.Optional = false
+		v.Computed = true
+	}
+	t["token_id"] = &schema.Schema{
+		Computed: true,
+		Type:     schema.TypeString,
+	}
+	s["ids"] = &schema.Schema{
+		Description: "IDs of all tokens that match the search criteria, most recently created first",
+		Type:        schema.TypeList,
+		Computed:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+	}
+	s["tokens"] = &schema.Schema{
+		Description: "All tokens that match the search criteria, most recently created first",
+		Type:        schema.TypeList,
+		Computed:    true,
+		Elem: &schema.Resource{
+			Schema: t,
+		},
+	}
+	return &schema.Resource{
+		Read:   dataTokensRead,
+		Schema: s,
+	}
+}
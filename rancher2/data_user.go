@@ -0,0 +1,188 @@
+package rancher2
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/rancher/norman/types"
+	"github.com/rancher/types/client/management/v3"
+)
+
+// userIsExternal checks whether the given user has been federated from an external authentication provider.
+func userIsExternal(u *client.User) bool {
+	for _, id := range u.PrincipalIDs {
+		if p, err := parsePrincipalID(id); err == nil && p.Provider != localPrincipalProvider {
+			return true
+		}
+	}
+	return false
+}
+
+// usersBySearchCriteria returns all users that match the search criteria given to a user data source.
+func usersBySearchCriteria(c *client.Client, d *schema.ResourceData) ([]client.User, error) {
+	filters := map[string]interface{}{}
+	if id := d.Get("user_id").(string); id != "" {
+		filters["id"] = id
+	}
+	if username := d.Get("username").(string); username != "" {
+		filters["username"] = username
+	}
+	if name := d.Get("name").(string); name != "" {
+		filters["name"] = name
+	}
+	collection, err := c.User.List(&types.ListOpts{
+		Filters: filters,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Rancher cannot filter by principal IDs, so we have to do that on our own.
+	principalID := d.Get("principal_id").(string)
+	isExternal, isExternalSet := d.GetOkExists("is_external")
+
+	users := make([]client.User, 0, len(collection.Data))
+	for collection != nil {
+		for _, user := range collection.Data {
+			if principalID != "" && !flattenStringSet(user.PrincipalIDs).Contains(principalID) {
+				continue
+			}
+			if isExternalSet && userIsExternal(&user) != isExternal.(bool) {
+				continue
+			}
+			users = append(users, user)
+		}
+		if collection, err = collection.Next(); err != nil {
+			return nil, err
+		}
+	}
+	return users, nil
+}
+
+// flattenUser converts the given user into the representation used by the user data sources.
+func flattenUser(c *client.Client, u *client.User) (map[string]interface{}, error) {
+	globalRoleIDs, err := userGlobalRoleIDs(c, u.ID)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"user_id":         u.ID,
+		"uuid":            u.UUID,
+		"username":        u.Username,
+		"name":            u.Name,
+		"description":     u.Description,
+		"enabled":         userEnabled(u),
+		"is_external":     userIsExternal(u),
+		"principal_ids":   u.PrincipalIDs,
+		"global_role_ids": globalRoleIDs,
+	}, nil
+}
+
+func dataUserRead(d *schema.ResourceData, m interface{}) error {
+	rancher := m.(Config).Rancher()
+
+	users, err := usersBySearchCriteria(rancher, d)
+	if err != nil {
+		return err
+	}
+
+	cnt := len(users)
+	if cnt <= 0 {
+		return fmt.Errorf("no user has been found that matches the search criteria")
+	}
+	if cnt > 1 {
+		return fmt.Errorf("more than one user matches the search criteria: %d", cnt)
+	}
+
+	// Only one user returned? Great...
+	user, err := flattenUser(rancher, &users[0])
+	if err != nil {
+		return err
+	}
+	d.SetId(users[0].ID)
+	for k, v := range user {
+		if err := d.Set(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// userSearchCriteriaSchema returns the schema of the attributes users can be searched by.
+func userSearchCriteriaSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"user_id": {
+			Description: "ID of the Rancher user",
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+		},
+		"username": {
+			Description: "Username (login) of the Rancher user",
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+		},
+		"name": {
+			Description: "Display name of the Rancher user",
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+		},
+		"principal_id": {
+			Description: "ID of a principal (e.g. \"activedirectory_user://CN=...\") associated with the Rancher user",
+			Type:        schema.TypeString,
+			Optional:    true,
+		},
+		"is_external": {
+			Description: "Whether the Rancher user has been federated from an external authentication provider",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Computed:    true,
+		},
+	}
+}
+
+// userAttributesSchema returns the schema of the attributes the user data sources report about users.
+func userAttributesSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"uuid": {
+			Description: "UUID of the Rancher user",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"description": {
+			Description: "Description of the Rancher user",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"enabled": {
+			Description: "Whether the Rancher user is allowed to log in",
+			Type:        schema.TypeBool,
+			Computed:    true,
+		},
+		"principal_ids": {
+			Description: "IDs of all principals associated with the Rancher user",
+			Type:        schema.TypeSet,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+		"global_role_ids": {
+			Description: "IDs of the global roles bound to the Rancher user",
+			Type:        schema.TypeSet,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+	}
+}
+
+func dataUser() *schema.Resource {
+	s := userSearchCriteriaSchema()
+	for k, v := range userAttributesSchema() {
+		s[k] = v
+	}
+	return &schema.Resource{
+		Read:   dataUserRead,
+		Schema: s,
+	}
+}
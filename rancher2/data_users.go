@@ -0,0 +1,73 @@
+package rancher2
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataUsersRead(d *schema.ResourceData, m interface{}) error {
+	rancher := m.(Config).Rancher()
+
+	users, err := usersBySearchCriteria(rancher, d)
+	if err != nil {
+		return err
+	}
+
+	ids := make([]string, 0, len(users))
+	flattened := make([]interface{}, 0, len(users))
+	for i := range users {
+		user, err := flattenUser(rancher, &users[i])
+		if err != nil {
+			return err
+		}
+		ids = append(ids, users[i].ID)
+		flattened = append(flattened, user)
+	}
+
+	// The ID of the data source is derived from the IDs of the users found.
+	sort.Strings(ids)
+	d.SetId(fmt.Sprintf("%x", sha1.Sum([]byte(strings.Join(ids, ",")))))
+	if err := d.Set("ids", ids); err != nil {
+		return err
+	}
+	return d.Set("users", flattened)
+}
+
+func dataUsers() *schema.Resource {
+	s := userSearchCriteriaSchema()
+	// The search criteria cannot be computed, as there are potentially many users found.
+	for _, v := range s {
+		v.Computed = false
+	}
+	user := userSearchCriteriaSchema()
+	delete(user, "principal_id")
+	for k, v := range userAttributesSchema() {
+		user[k] = v
+	}
+	for _, v := range user {
+		v.Optional = false
+		v.Computed = true
+	}
+	s["ids"] = &schema.Schema{
+		Description: "IDs of all Rancher users that match the search criteria",
+		Type:        schema.TypeList,
+		Computed:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+	}
+	s["users"] = &schema.Schema{
+		Description: "All Rancher users that match the search criteria",
+		Type:        schema.TypeList,
+		Computed:    true,
+		Elem: &schema.Resource{
+			Schema: user,
+		},
+	}
+	return &schema.Resource{
+		Read:   dataUsersRead,
+		Schema: s,
+	}
+}
@@ -0,0 +1,489 @@
+package rancher2
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/rancher/types/client/management/v3"
+)
+
+// loggingTargetAttributes lists the attributes configuring where logs are forwarded to. Exactly one of them
+// has to be given.
+var loggingTargetAttributes = []string{"elasticsearch_config", "splunk_config", "kafka_config", "syslog_config", "fluentd_config"}
+
+// loggingTargets holds the targets of cluster and project logging. The fluentd forwarder is not known to the
+// version of the Rancher client library we are using, so we define the API types of logging on our own.
+type loggingTargets struct {
+	ElasticsearchConfig   *client.ElasticsearchConfig `json:"elasticsearchConfig,omitempty" yaml:"elasticsearchConfig,omitempty"`
+	SplunkConfig          *client.SplunkConfig        `json:"splunkConfig,omitempty" yaml:"splunkConfig,omitempty"`
+	KafkaConfig           *client.KafkaConfig         `json:"kafkaConfig,omitempty" yaml:"kafkaConfig,omitempty"`
+	SyslogConfig          *client.SyslogConfig        `json:"syslogConfig,omitempty" yaml:"syslogConfig,omitempty"`
+	FluentForwarderConfig *fluentForwarderConfig      `json:"fluentForwarderConfig,omitempty" yaml:"fluentForwarderConfig,omitempty"`
+}
+
+// fluentForwarderConfig forwards logs to fluentd servers.
+type fluentForwarderConfig struct {
+	EnableTLS     bool           `json:"enableTls,omitempty" yaml:"enableTls,omitempty"`
+	Certificate   string         `json:"certificate,omitempty" yaml:"certificate,omitempty"`
+	Compress      bool           `json:"compress,omitempty" yaml:"compress,omitempty"`
+	FluentServers []fluentServer `json:"fluentServers,omitempty" yaml:"fluentServers,omitempty"`
+}
+
+// fluentServer is a fluentd server logs are forwarded to.
+type fluentServer struct {
+	Endpoint  string `json:"endpoint,omitempty" yaml:"endpoint,omitempty"`
+	Hostname  string `json:"hostname,omitempty" yaml:"hostname,omitempty"`
+	Weight    int64  `json:"weight,omitempty" yaml:"weight,omitempty"`
+	Standby   bool   `json:"standby,omitempty" yaml:"standby,omitempty"`
+	Username  string `json:"username,omitempty" yaml:"username,omitempty"`
+	Password  string `json:"password,omitempty" yaml:"password,omitempty"`
+	SharedKey string `json:"sharedKey,omitempty" yaml:"sharedKey,omitempty"`
+}
+
+// updates returns the targets as updates of the API object. Targets which are not given are sent as well,
+// as they have to be removed explicitly.
+func (t *loggingTargets) updates() map[string]interface{} {
+	return map[string]interface{}{
+		"elasticsearchConfig":   t.ElasticsearchConfig,
+		"splunkConfig":          t.SplunkConfig,
+		"kafkaConfig":           t.KafkaConfig,
+		"syslogConfig":          t.SyslogConfig,
+		"fluentForwarderConfig": t.FluentForwarderConfig,
+	}
+}
+
+// expandLoggingTargets converts the configured logging target to its API representation.
+func expandLoggingTargets(d *schema.ResourceData) (*loggingTargets, error) {
+	targets := &loggingTargets{}
+	configs := 0
+	if config := configBlock(d.Get("elasticsearch_config")); config != nil {
+		configs++
+		targets.ElasticsearchConfig = &client.ElasticsearchConfig{
+			Endpoint:      config["endpoint"].(string),
+			IndexPrefix:   config["index_prefix"].(string),
+			DateFormat:    config["date_format"].(string),
+			AuthUserName:  config["auth_username"].(string),
+			AuthPassword:  config["auth_password"].(string),
+			Certificate:   config["certificate"].(string),
+			ClientCert:    config["client_cert"].(string),
+			ClientKey:     config["client_key"].(string),
+			ClientKeyPass: config["client_key_pass"].(string),
+			SSLVerify:     config["ssl_verify"].(bool),
+		}
+	}
+	if config := configBlock(d.Get("splunk_config")); config != nil {
+		configs++
+		targets.SplunkConfig = &client.SplunkConfig{
+			Endpoint:      config["endpoint"].(string),
+			Token:         config["token"].(string),
+			Index:         config["index"].(string),
+			Source:        config["source"].(string),
+			Certificate:   config["certificate"].(string),
+			ClientCert:    config["client_cert"].(string),
+			ClientKey:     config["client_key"].(string),
+			ClientKeyPass: config["client_key_pass"].(string),
+			SSLVerify:     config["ssl_verify"].(bool),
+		}
+	}
+	if config := configBlock(d.Get("kafka_config")); config != nil {
+		configs++
+		targets.KafkaConfig = &client.KafkaConfig{
+			BrokerEndpoints:   expandStringList(config["broker_endpoints"]),
+			ZookeeperEndpoint: config["zookeeper_endpoint"].(string),
+			Topic:             config["topic"].(string),
+			Certificate:       config["certificate"].(string),
+			ClientCert:        config["client_cert"].(string),
+			ClientKey:         config["client_key"].(string),
+		}
+		if (len(targets.KafkaConfig.BrokerEndpoints) == 0) == (targets.KafkaConfig.ZookeeperEndpoint == "") {
+			return nil, fmt.Errorf("exactly one of broker_endpoints and zookeeper_endpoint has to be given for Kafka")
+		}
+	}
+	if config := configBlock(d.Get("syslog_config")); config != nil {
+		configs++
+		targets.SyslogConfig = &client.SyslogConfig{
+			Endpoint:    config["endpoint"].(string),
+			Protocol:    config["protocol"].(string),
+			Severity:    config["severity"].(string),
+			Program:     config["program"].(string),
+			Token:       config["token"].(string),
+			Certificate: config["certificate"].(string),
+			ClientCert:  config["client_cert"].(string),
+			ClientKey:   config["client_key"].(string),
+			SSLVerify:   config["ssl_verify"].(bool),
+		}
+	}
+	if config := configBlock(d.Get("fluentd_config")); config != nil {
+		configs++
+		servers := config["fluent_server"].([]interface{})
+		targets.FluentForwarderConfig = &fluentForwarderConfig{
+			EnableTLS:     config["enable_tls"].(bool),
+			Certificate:   config["certificate"].(string),
+			Compress:      config["compress"].(bool),
+			FluentServers: make([]fluentServer, 0, len(servers)),
+		}
+		for _, s := range servers {
+			server := s.(map[string]interface{})
+			targets.FluentForwarderConfig.FluentServers = append(targets.FluentForwarderConfig.FluentServers, fluentServer{
+				Endpoint:  server["endpoint"].(string),
+				Hostname:  server["hostname"].(string),
+				Weight:    int64(server["weight"].(int)),
+				Standby:   server["standby"].(bool),
+				Username:  server["username"].(string),
+				Password:  server["password"].(string),
+				SharedKey: server["shared_key"].(string),
+			})
+		}
+	}
+	if configs != 1 {
+		return nil, fmt.Errorf("exactly one of %s has to be given", strings.Join(loggingTargetAttributes, ", "))
+	}
+	return targets, nil
+}
+
+// flattenLoggingTargets updates the terraform state with the given logging targets. Secrets which are not
+// reported by Rancher are kept as configured.
+func flattenLoggingTargets(d *schema.ResourceData, targets *loggingTargets) error {
+	configs := map[string][]interface{}{}
+	for _, k := range loggingTargetAttributes {
+		configs[k] = []interface{}{}
+	}
+	if config := targets.ElasticsearchConfig; config != nil {
+		configs["elasticsearch_config"] = []interface{}{map[string]interface{}{
+			"endpoint":        config.Endpoint,
+			"index_prefix":    config.IndexPrefix,
+			"date_format":     config.DateFormat,
+			"auth_username":   config.AuthUserName,
+			"auth_password":   reportedSecret(d, "elasticsearch_config.0.auth_password", config.AuthPassword),
+			"certificate":     config.Certificate,
+			"client_cert":     config.ClientCert,
+			"client_key":      reportedSecret(d, "elasticsearch_config.0.client_key", config.ClientKey),
+			"client_key_pass": reportedSecret(d, "elasticsearch_config.0.client_key_pass", config.ClientKeyPass),
+			"ssl_verify":      config.SSLVerify,
+		}}
+	}
+	if config := targets.SplunkConfig; config != nil {
+		configs["splunk_config"] = []interface{}{map[string]interface{}{
+			"endpoint":        config.Endpoint,
+			"token":           reportedSecret(d, "splunk_config.0.token", config.Token),
+			"index":           config.Index,
+			"source":          config.Source,
+			"certificate":     config.Certificate,
+			"client_cert":     config.ClientCert,
+			"client_key":      reportedSecret(d, "splunk_config.0.client_key", config.ClientKey),
+			"client_key_pass": reportedSecret(d, "splunk_config.0.client_key_pass", config.ClientKeyPass),
+			"ssl_verify":      config.SSLVerify,
+		}}
+	}
+	if config := targets.KafkaConfig; config != nil {
+		configs["kafka_config"] = []interface{}{map[string]interface{}{
+			"broker_endpoints":   config.BrokerEndpoints,
+			"zookeeper_endpoint": config.ZookeeperEndpoint,
+			"topic":              config.Topic,
+			"certificate":        config.Certificate,
+			"client_cert":        config.ClientCert,
+			"client_key":         reportedSecret(d, "kafka_config.0.client_key", config.ClientKey),
+		}}
+	}
+	if config := targets.SyslogConfig; config != nil {
+		configs["syslog_config"] = []interface{}{map[string]interface{}{
+			"endpoint":    config.Endpoint,
+			"protocol":    config.Protocol,
+			"severity":    config.Severity,
+			"program":     config.Program,
+			"token":       reportedSecret(d, "syslog_config.0.token", config.Token),
+			"certificate": config.Certificate,
+			"client_cert": config.ClientCert,
+			"client_key":  reportedSecret(d, "syslog_config.0.client_key", config.ClientKey),
+			"ssl_verify":  config.SSLVerify,
+		}}
+	}
+	if config := targets.FluentForwarderConfig; config != nil {
+		servers := make([]interface{}, 0, len(config.FluentServers))
+		for i, s := range config.FluentServers {
+			key := fmt.Sprintf("fluentd_config.0.fluent_server.%d", i)
+			servers = append(servers, map[string]interface{}{
+				"endpoint":   s.Endpoint,
+				"hostname":   s.Hostname,
+				"weight":     int(s.Weight),
+				"standby":    s.Standby,
+				"username":   s.Username,
+				"password":   reportedSecret(d, key+".password", s.Password),
+				"shared_key": reportedSecret(d, key+".shared_key", s.SharedKey),
+			})
+		}
+		configs["fluentd_config"] = []interface{}{map[string]interface{}{
+			"enable_tls":    config.EnableTLS,
+			"certificate":   config.Certificate,
+			"compress":      config.Compress,
+			"fluent_server": servers,
+		}}
+	}
+	for k, v := range configs {
+		if err := d.Set(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loggingTLSSchema returns the schema of the certificates used to connect to a logging target.
+func loggingTLSSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"certificate": {
+			Description: "CA certificate of the target in PEM format",
+			Type:        schema.TypeString,
+			Optional:    true,
+			Sensitive:   true,
+		},
+		"client_cert": {
+			Description: "Client certificate to authenticate with at the target in PEM format",
+			Type:        schema.TypeString,
+			Optional:    true,
+			Sensitive:   true,
+		},
+		"client_key": {
+			Description: "Private key of the client certificate in PEM format",
+			Type:        schema.TypeString,
+			Optional:    true,
+			Sensitive:   true,
+		},
+	}
+}
+
+// loggingTargetSchema returns the schema of a logging target, which conflicts with all other targets.
+func loggingTargetSchema(description string, target string, s map[string]*schema.Schema) *schema.Schema {
+	return &schema.Schema{
+		Description:   description,
+		Type:          schema.TypeList,
+		Optional:      true,
+		MaxItems:      1,
+		ConflictsWith: conflictingAttributes(loggingTargetAttributes, target),
+		Elem: &schema.Resource{
+			Schema: s,
+		},
+	}
+}
+
+// loggingSchema returns the schema of the attributes shared by cluster and project logging, including all of
+// the logging targets.
+func loggingSchema() map[string]*schema.Schema {
+	sslVerify := func() *schema.Schema {
+		return &schema.Schema{
+			Description: "Whether to verify the certificate of the target",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+		}
+	}
+	clientKeyPass := func() *schema.Schema {
+		return &schema.Schema{
+			Description: "Passphrase of the private key of the client certificate",
+			Type:        schema.TypeString,
+			Optional:    true,
+			Sensitive:   true,
+		}
+	}
+	return map[string]*schema.Schema{
+		"output_flush_interval": {
+			Description: "Seconds to buffer logs before flushing them to the target",
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Default:     3,
+		},
+		"output_tags": {
+			Description: "Tags added to all forwarded logs",
+			Type:        schema.TypeMap,
+			Optional:    true,
+		},
+		"elasticsearch_config": loggingTargetSchema("Forwards logs to Elasticsearch", "elasticsearch_config", mergeSchemas(loggingTLSSchema(), map[string]*schema.Schema{
+			"endpoint": {
+				Description: "URL of Elasticsearch (e.g. \"https://elasticsearch.example.com:9200\")",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"index_prefix": {
+				Description: "Prefix of the indices logs are written to",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"date_format": {
+				Description:  "Date format appended to the index prefix",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "YYYY-MM-DD",
+				ValidateFunc: validation.StringInSlice([]string{"YYYY-MM-DD", "YYYY-MM", "YYYY"}, false),
+			},
+			"auth_username": {
+				Description: "Username to authenticate with at Elasticsearch",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"auth_password": {
+				Description: "Password to authenticate with at Elasticsearch",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"client_key_pass": clientKeyPass(),
+			"ssl_verify":      sslVerify(),
+		})),
+		"splunk_config": loggingTargetSchema("Forwards logs to the HTTP event collector of Splunk", "splunk_config", mergeSchemas(loggingTLSSchema(), map[string]*schema.Schema{
+			"endpoint": {
+				Description: "URL of the HTTP event collector (e.g. \"https://splunk.example.com:8088\")",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"token": {
+				Description: "Token of the HTTP event collector",
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+			},
+			"index": {
+				Description: "Index logs are written to",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"source": {
+				Description: "Source logs are tagged with",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"client_key_pass": clientKeyPass(),
+			"ssl_verify":      sslVerify(),
+		})),
+		"kafka_config": loggingTargetSchema("Forwards logs to Kafka", "kafka_config", mergeSchemas(loggingTLSSchema(), map[string]*schema.Schema{
+			"broker_endpoints": {
+				Description: "URLs of the Kafka brokers, conflicts with zookeeper_endpoint",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"zookeeper_endpoint": {
+				Description: "URL of ZooKeeper the brokers are looked up at, conflicts with broker_endpoints",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"topic": {
+				Description: "Topic logs are written to",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+		})),
+		"syslog_config": loggingTargetSchema("Forwards logs to a syslog server", "syslog_config", mergeSchemas(loggingTLSSchema(), map[string]*schema.Schema{
+			"endpoint": {
+				Description: "Host and port of the syslog server (e.g. \"syslog.example.com:514\")",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"protocol": {
+				Description:  "Protocol to connect to the syslog server with (\"udp\" or \"tcp\")",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "udp",
+				ValidateFunc: validation.StringInSlice([]string{"udp", "tcp"}, false),
+			},
+			"severity": {
+				Description:  "Severity logs are sent with",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "notice",
+				ValidateFunc: validation.StringInSlice([]string{"emerg", "alert", "crit", "err", "warning", "notice", "info", "debug"}, false),
+			},
+			"program": {
+				Description: "Program name logs are sent with",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"token": {
+				Description: "Token logs are sent with, as required by some hosted syslog services",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"ssl_verify": sslVerify(),
+		})),
+		"fluentd_config": loggingTargetSchema("Forwards logs to fluentd servers", "fluentd_config", map[string]*schema.Schema{
+			"enable_tls": {
+				Description: "Whether to connect to the fluentd servers via TLS",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"certificate": {
+				Description: "CA certificate of the fluentd servers in PEM format",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"compress": {
+				Description: "Whether to compress forwarded logs",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"fluent_server": {
+				Description: "fluentd servers logs are forwarded to",
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"endpoint": {
+							Description: "Host and port of the fluentd server (e.g. \"fluentd.example.com:24224\")",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"hostname": {
+							Description: "Host name of the fluentd server, as verified with TLS",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"weight": {
+							Description: "Weight of the fluentd server for load balancing",
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Default:     100,
+						},
+						"standby": {
+							Description: "Whether the fluentd server is only used if the other ones are unavailable",
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+						},
+						"username": {
+							Description: "Username to authenticate with at the fluentd server",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"password": {
+							Description: "Password to authenticate with at the fluentd server",
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+						},
+						"shared_key": {
+							Description: "Shared key to authenticate with at the fluentd server",
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+						},
+					},
+				},
+			},
+		}),
+		"state": {
+			Description: "State of the logging as reported by the Rancher API",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"uuid": {
+			Description: "UUID of the logging as reported by the Rancher API",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+	}
+}
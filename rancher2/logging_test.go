@@ -0,0 +1,57 @@
+package rancher2
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/rancher/types/client/management/v3"
+)
+
+func TestExpandLoggingTargets(t *testing.T) {
+	tt := []struct {
+		raw         map[string]interface{}
+		errExpected bool
+	}{
+		{raw: map[string]interface{}{
+			"elasticsearch_config": []interface{}{map[string]interface{}{"endpoint": "https://es.example.com:9200", "index_prefix": "audit"}},
+		}},
+		{raw: map[string]interface{}{
+			"kafka_config": []interface{}{map[string]interface{}{"broker_endpoints": []interface{}{"kafka.example.com:9092"}, "topic": "logs"}},
+		}},
+		{raw: map[string]interface{}{
+			"kafka_config": []interface{}{map[string]interface{}{"topic": "logs"}},
+		}, errExpected: true},
+		{raw: map[string]interface{}{
+			"fluentd_config": []interface{}{map[string]interface{}{
+				"fluent_server": []interface{}{map[string]interface{}{"endpoint": "fluentd.example.com:24224", "shared_key": "s3cr3t"}},
+			}},
+		}},
+		{raw: map[string]interface{}{}, errExpected: true},
+		{raw: map[string]interface{}{
+			"splunk_config": []interface{}{map[string]interface{}{"endpoint": "https://splunk.example.com:8088", "token": "t"}},
+			"syslog_config": []interface{}{map[string]interface{}{"endpoint": "syslog.example.com:514"}},
+		}, errExpected: true},
+	}
+	for i, td := range tt {
+		d := schema.TestResourceDataRaw(t, loggingSchema(), td.raw)
+		if _, err := expandLoggingTargets(d); (td.errExpected && err == nil) || (!td.errExpected && err != nil) {
+			t.Errorf("unexpected error result for logging %d: %v", i, err)
+		}
+	}
+}
+
+func TestFlattenLoggingTargetsKeepsSecrets(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, loggingSchema(), map[string]interface{}{
+		"splunk_config": []interface{}{map[string]interface{}{"endpoint": "https://splunk.example.com:8088", "token": "s3cr3t"}},
+	})
+	targets := &loggingTargets{SplunkConfig: &client.SplunkConfig{Endpoint: "https://splunk.example.com:8088", Index: "audit"}}
+	if err := flattenLoggingTargets(d, targets); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if token := d.Get("splunk_config.0.token").(string); token != "s3cr3t" {
+		t.Errorf("unexpected token: %s", token)
+	}
+	if index := d.Get("splunk_config.0.index").(string); index != "audit" {
+		t.Errorf("unexpected index: %s", index)
+	}
+}
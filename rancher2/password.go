@@ -0,0 +1,157 @@
+package rancher2
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+const (
+	passwordCharsLower   = "abcdefghijklmnopqrstuvwxyz"
+	passwordCharsUpper   = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	passwordCharsNumeric = "0123456789"
+	passwordCharsSpecial = "!#$%&*+-.:=?@^_~"
+)
+
+// passwordPolicy describes what generated passwords have to look like.
+type passwordPolicy struct {
+	Length  int
+	Lower   bool
+	Upper   bool
+	Numeric bool
+	Special bool
+}
+
+// defaultPasswordPolicy is used if no password policy has been configured.
+var defaultPasswordPolicy = passwordPolicy{
+	Length:  32,
+	Lower:   true,
+	Upper:   true,
+	Numeric: true,
+	Special: true,
+}
+
+// expandPasswordPolicy converts the configured password policy into its internal representation.
+func expandPasswordPolicy(v interface{}) passwordPolicy {
+	list := v.([]interface{})
+	if len(list) == 0 || list[0] == nil {
+		return defaultPasswordPolicy
+	}
+	policy := list[0].(map[string]interface{})
+	return passwordPolicy{
+		Length:  policy["length"].(int),
+		Lower:   policy["lower"].(bool),
+		Upper:   policy["upper"].(bool),
+		Numeric: policy["numeric"].(bool),
+		Special: policy["special"].(bool),
+	}
+}
+
+// randomInt returns a uniformly distributed, cryptographically secure random number in [0, max).
+func randomInt(max int) (int, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(max)))
+	if err != nil {
+		return 0, fmt.Errorf("unable to generate random number: %v", err)
+	}
+	return int(n.Int64()), nil
+}
+
+// generatePassword generates a random password that complies with the given policy. The password contains
+// at least one character of each of the enabled character classes.
+func generatePassword(policy passwordPolicy) (string, error) {
+	classes := make([]string, 0, 4)
+	if policy.Lower {
+		classes = append(classes, passwordCharsLower)
+	}
+	if policy.Upper {
+		classes = append(classes, passwordCharsUpper)
+	}
+	if policy.Numeric {
+		classes = append(classes, passwordCharsNumeric)
+	}
+	if policy.Special {
+		classes = append(classes, passwordCharsSpecial)
+	}
+	if len(classes) == 0 {
+		return "", fmt.Errorf("password policy has to allow at least one character class")
+	}
+	if policy.Length < len(classes) {
+		return "", fmt.Errorf("password length %d is too short to contain all %d required character classes", policy.Length, len(classes))
+	}
+
+	allChars := ""
+	for _, chars := range classes {
+		allChars += chars
+	}
+
+	password := make([]byte, policy.Length)
+	for i := range password {
+		// The first characters ensure that each character class is used at least once...
+		chars := allChars
+		if i < len(classes) {
+			chars = classes[i]
+		}
+		idx, err := randomInt(len(chars))
+		if err != nil {
+			return "", err
+		}
+		password[i] = chars[idx]
+	}
+
+	// ...so we have to shuffle them to not give away their positions.
+	for i := len(password) - 1; i > 0; i-- {
+		j, err := randomInt(i + 1)
+		if err != nil {
+			return "", err
+		}
+		password[i], password[j] = password[j], password[i]
+	}
+	return string(password), nil
+}
+
+// passwordPolicySchema returns the schema of the policy passwords are generated with.
+func passwordPolicySchema() *schema.Schema {
+	return &schema.Schema{
+		Description:   "Policy to generate the password with if none has been given",
+		Type:          schema.TypeList,
+		Optional:      true,
+		MaxItems:      1,
+		ConflictsWith: []string{"password"},
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"length": {
+					Description: "Length of the generated password",
+					Type:        schema.TypeInt,
+					Optional:    true,
+					Default:     defaultPasswordPolicy.Length,
+				},
+				"lower": {
+					Description: "Whether the generated password shall contain lower case letters",
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     defaultPasswordPolicy.Lower,
+				},
+				"upper": {
+					Description: "Whether the generated password shall contain upper case letters",
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     defaultPasswordPolicy.Upper,
+				},
+				"numeric": {
+					Description: "Whether the generated password shall contain digits",
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     defaultPasswordPolicy.Numeric,
+				},
+				"special": {
+					Description: "Whether the generated password shall contain special characters",
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     defaultPasswordPolicy.Special,
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,61 @@
+package rancher2
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGeneratePassword(t *testing.T) {
+	tt := []struct {
+		policy      passwordPolicy
+		errExpected bool
+	}{
+		{policy: defaultPasswordPolicy},
+		{policy: passwordPolicy{Length: 4, Lower: true, Upper: true, Numeric: true, Special: true}},
+		{policy: passwordPolicy{Length: 16, Numeric: true}},
+		{policy: passwordPolicy{Length: 64, Lower: true, Special: true}},
+		{policy: passwordPolicy{Length: 3, Lower: true, Upper: true, Numeric: true, Special: true}, errExpected: true},
+		{policy: passwordPolicy{Length: 16}, errExpected: true},
+	}
+	for _, td := range tt {
+		password, err := generatePassword(td.policy)
+		if (td.errExpected && err == nil) || (!td.errExpected && err != nil) {
+			t.Errorf("unexpected error result for policy %+v: %v", td.policy, err)
+			continue
+		}
+		if td.errExpected {
+			continue
+		}
+		if len(password) != td.policy.Length {
+			t.Errorf("unexpected password length for policy %+v: %d", td.policy, len(password))
+		}
+		classes := []struct {
+			enabled bool
+			chars   string
+		}{
+			{td.policy.Lower, passwordCharsLower},
+			{td.policy.Upper, passwordCharsUpper},
+			{td.policy.Numeric, passwordCharsNumeric},
+			{td.policy.Special, passwordCharsSpecial},
+		}
+		for _, class := range classes {
+			if contains := strings.ContainsAny(password, class.chars); contains != class.enabled {
+				t.Errorf("password \"%s\" for policy %+v violates character class \"%s\"", password, td.policy, class.chars)
+			}
+		}
+	}
+}
+
+func TestGeneratePasswordIsRandom(t *testing.T) {
+	passwords := map[string]bool{}
+	for i := 0; i < 100; i++ {
+		password, err := generatePassword(defaultPasswordPolicy)
+		if err != nil {
+			t.Fatalf("unable to generate password: %v", err)
+		}
+		if passwords[password] {
+			t.Fatalf("password \"%s\" has been generated twice", password)
+		}
+		passwords[password] = true
+	}
+}
@@ -0,0 +1,56 @@
+package rancher2
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/openpgp"
+	// Keys that do not state any hash preferences have to be assumed to support RIPEMD-160 only.
+	_ "golang.org/x/crypto/ripemd160"
+)
+
+// readPGPPublicKey reads the PGP public key given either ASCII-armored or base64-encoded.
+func readPGPPublicKey(key string) (*openpgp.Entity, error) {
+	var entities openpgp.EntityList
+	var err error
+	if strings.HasPrefix(strings.TrimSpace(key), "-----BEGIN") {
+		entities, err = openpgp.ReadArmoredKeyRing(strings.NewReader(key))
+	} else {
+		var raw []byte
+		if raw, err = base64.StdEncoding.DecodeString(strings.TrimSpace(key)); err != nil {
+			return nil, fmt.Errorf("unable to decode base64-encoded PGP public key: %v", err)
+		}
+		entities, err = openpgp.ReadKeyRing(bytes.NewReader(raw))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read PGP public key: %v", err)
+	}
+	if len(entities) != 1 {
+		return nil, fmt.Errorf("expected exactly one PGP public key, got %d", len(entities))
+	}
+	return entities[0], nil
+}
+
+// encryptWithPGPKey encrypts the given value for the owner of the given PGP public key. It returns the
+// fingerprint of the key and the base64-encoded encrypted value.
+func encryptWithPGPKey(key string, value string) (fingerprint string, encrypted string, err error) {
+	entity, err := readPGPPublicKey(key)
+	if err != nil {
+		return "", "", err
+	}
+	buf := &bytes.Buffer{}
+	w, err := openpgp.Encrypt(buf, []*openpgp.Entity{entity}, nil, nil, nil)
+	if err != nil {
+		return "", "", fmt.Errorf("unable to encrypt value: %v", err)
+	}
+	if _, err = w.Write([]byte(value)); err != nil {
+		return "", "", fmt.Errorf("unable to encrypt value: %v", err)
+	}
+	if err = w.Close(); err != nil {
+		return "", "", fmt.Errorf("unable to encrypt value: %v", err)
+	}
+	return hex.EncodeToString(entity.PrimaryKey.Fingerprint[:]), base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
@@ -0,0 +1,65 @@
+package rancher2
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"io/ioutil"
+	"testing"
+
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/armor"
+)
+
+func TestEncryptWithPGPKey(t *testing.T) {
+	entity, err := openpgp.NewEntity("Jane Doe", "", "jane.doe@example.com", nil)
+	if err != nil {
+		t.Fatalf("unable to generate PGP key: %v", err)
+	}
+	// Self-signatures of new keys are only created when serializing the private key...
+	if err := entity.SerializePrivate(ioutil.Discard, nil); err != nil {
+		t.Fatalf("unable to sign PGP key: %v", err)
+	}
+	raw := &bytes.Buffer{}
+	if err := entity.Serialize(raw); err != nil {
+		t.Fatalf("unable to serialize PGP public key: %v", err)
+	}
+	armored := &bytes.Buffer{}
+	w, err := armor.Encode(armored, openpgp.PublicKeyType, nil)
+	if err != nil {
+		t.Fatalf("unable to armor PGP public key: %v", err)
+	}
+	if err := entity.Serialize(w); err != nil {
+		t.Fatalf("unable to serialize PGP public key: %v", err)
+	}
+	w.Close()
+
+	for _, key := range []string{base64.StdEncoding.EncodeToString(raw.Bytes()), armored.String()} {
+		fingerprint, encrypted, err := encryptWithPGPKey(key, "token-abcde:secret")
+		if err != nil {
+			t.Fatalf("unable to encrypt value: %v", err)
+		}
+		if fingerprint != hex.EncodeToString(entity.PrimaryKey.Fingerprint[:]) {
+			t.Errorf("unexpected fingerprint: %s", fingerprint)
+		}
+		ciphertext, err := base64.StdEncoding.DecodeString(encrypted)
+		if err != nil {
+			t.Fatalf("unable to decode encrypted value: %v", err)
+		}
+		md, err := openpgp.ReadMessage(bytes.NewReader(ciphertext), openpgp.EntityList{entity}, nil, nil)
+		if err != nil {
+			t.Fatalf("unable to decrypt value: %v", err)
+		}
+		plaintext, err := ioutil.ReadAll(md.UnverifiedBody)
+		if err != nil {
+			t.Fatalf("unable to decrypt value: %v", err)
+		}
+		if string(plaintext) != "token-abcde:secret" {
+			t.Errorf("unexpected decrypted value: %s", plaintext)
+		}
+	}
+
+	if _, _, err := encryptWithPGPKey("not a key", "secret"); err == nil {
+		t.Errorf("expected error for invalid PGP public key")
+	}
+}
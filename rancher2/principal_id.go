@@ -0,0 +1,68 @@
+package rancher2
+
+import (
+	"fmt"
+	"strings"
+)
+
+const principalIDSeparator = "://"
+
+// localPrincipalProvider is the provider of the principal Rancher itself assigns to each of its users.
+const localPrincipalProvider = "local"
+
+// externalUserPrincipalTypes lists the types of user principals of external authentication providers that
+// can be assigned to Rancher users via dedicated attributes.
+var externalUserPrincipalTypes = []string{
+	"activedirectory_user",
+	"openldap_user",
+	"freeipa_user",
+	"github_user",
+	"azuread_user",
+	"ping_user",
+}
+
+// principalID represents the ID of a principal such as "activedirectory_user://CN=Doe\, John,OU=Users"
+// or "local://u-abcde".
+type principalID struct {
+	// Provider is the authentication provider of the principal, e.g. "activedirectory" or "local".
+	Provider string
+	// Kind is the kind of principal, e.g. "user" or "group". It is empty for local principals.
+	Kind string
+	// Name identifies the principal within the authentication provider, e.g. a DN or a numeric ID.
+	Name string
+}
+
+// parsePrincipalID parses the given principal ID.
+func parsePrincipalID(id string) (*principalID, error) {
+	idx := strings.Index(id, principalIDSeparator)
+	if idx <= 0 {
+		return nil, fmt.Errorf("invalid principal ID \"%s\": expected \"<provider>_<kind>%s<name>\"", id, principalIDSeparator)
+	}
+	name := id[idx+len(principalIDSeparator):]
+	if name == "" {
+		return nil, fmt.Errorf("invalid principal ID \"%s\": name is missing", id)
+	}
+	p := &principalID{Provider: id[:idx], Name: name}
+	if kindIdx := strings.LastIndex(p.Provider, "_"); kindIdx > 0 {
+		p.Kind = p.Provider[kindIdx+1:]
+		p.Provider = p.Provider[:kindIdx]
+	}
+	return p, nil
+}
+
+// Type returns the principal type as used as prefix of the principal ID, e.g. "activedirectory_user".
+func (p *principalID) Type() string {
+	if p.Kind == "" {
+		return p.Provider
+	}
+	return fmt.Sprintf("%s_%s", p.Provider, p.Kind)
+}
+
+func (p *principalID) String() string {
+	return formatPrincipalID(p.Type(), p.Name)
+}
+
+// formatPrincipalID returns the ID of the principal of the given type (e.g. "activedirectory_user") and name.
+func formatPrincipalID(principalType string, name string) string {
+	return fmt.Sprintf("%s%s%s", principalType, principalIDSeparator, name)
+}
@@ -0,0 +1,59 @@
+package rancher2
+
+import (
+	"testing"
+)
+
+func TestParsePrincipalID(t *testing.T) {
+	tt := []struct {
+		id          string
+		expected    principalID
+		errExpected bool
+	}{
+		{
+			id:       "activedirectory_user://CN=Doe\\, John,OU=Software Development,OU=user",
+			expected: principalID{Provider: "activedirectory", Kind: "user", Name: "CN=Doe\\, John,OU=Software Development,OU=user"},
+		},
+		{
+			id:       "openldap_group://cn=admins,ou=groups,dc=example,dc=com",
+			expected: principalID{Provider: "openldap", Kind: "group", Name: "cn=admins,ou=groups,dc=example,dc=com"},
+		},
+		{
+			id:       "github_user://1234567",
+			expected: principalID{Provider: "github", Kind: "user", Name: "1234567"},
+		},
+		{
+			id:       "ping_user://jane.doe@example.com",
+			expected: principalID{Provider: "ping", Kind: "user", Name: "jane.doe@example.com"},
+		},
+		{
+			id:       "local://u-abcde",
+			expected: principalID{Provider: "local", Name: "u-abcde"},
+		},
+		{
+			// Only the first separator counts, names may contain further ones.
+			id:       "azuread_user://https://sts.windows.net/tenant/user",
+			expected: principalID{Provider: "azuread", Kind: "user", Name: "https://sts.windows.net/tenant/user"},
+		},
+		{id: "", errExpected: true},
+		{id: "CN=Doe,OU=user", errExpected: true},
+		{id: "://u-abcde", errExpected: true},
+		{id: "activedirectory_user://", errExpected: true},
+	}
+	for _, td := range tt {
+		p, err := parsePrincipalID(td.id)
+		if (td.errExpected && err == nil) || (!td.errExpected && err != nil) {
+			t.Errorf("unexpected error result for \"%s\": %v", td.id, err)
+			continue
+		}
+		if td.errExpected {
+			continue
+		}
+		if *p != td.expected {
+			t.Errorf("unexpected result for \"%s\": expected %+v, got %+v", td.id, td.expected, *p)
+		}
+		if p.String() != td.id {
+			t.Errorf("principal ID \"%s\" does not round-trip, got \"%s\"", td.id, p.String())
+		}
+	}
+}
@@ -57,16 +57,47 @@ func Provider() *schema.Provider {
 			},
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"rancher2_caller_identity": dataCallerIdentity(),
-			"rancher2_project":         dataProject(),
-			"rancher2_token":           dataToken(),
+			"rancher2_caller_identity":  dataCallerIdentity(),
+			"rancher2_catalog_template": dataCatalogTemplate(),
+			"rancher2_principal":        dataPrincipal(),
+			"rancher2_project":          dataProject(),
+			"rancher2_role_template":    dataRoleTemplate(),
+			"rancher2_setting":          dataSetting(),
+			"rancher2_token":            dataToken(),
+			"rancher2_tokens":           dataTokens(),
+			"rancher2_user":             dataUser(),
+			"rancher2_users":            dataUsers(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"rancher2_cluster":                    resourceCluster(),
-			"rancher2_cluster_registration_token": resourceClusterRegistrationToken(),
-			"rancher2_project":                    resourceProject(),
-			"rancher2_token":                      resourceToken(),
-			"rancher2_user":                       resourceUser(),
+			"rancher2_app":                           resourceApp(),
+			"rancher2_auth_config_activedirectory":   resourceAuthConfigActiveDirectory(),
+			"rancher2_auth_config_adfs":              resourceAuthConfigADFS(),
+			"rancher2_auth_config_azuread":           resourceAuthConfigAzureAD(),
+			"rancher2_auth_config_freeipa":           resourceAuthConfigFreeIpa(),
+			"rancher2_auth_config_github":            resourceAuthConfigGithub(),
+			"rancher2_auth_config_keycloak":          resourceAuthConfigKeycloak(),
+			"rancher2_auth_config_local":             resourceAuthConfigLocal(),
+			"rancher2_auth_config_okta":              resourceAuthConfigOkta(),
+			"rancher2_auth_config_openldap":          resourceAuthConfigOpenLdap(),
+			"rancher2_auth_config_ping":              resourceAuthConfigPing(),
+			"rancher2_catalog":                       resourceCatalog(),
+			"rancher2_cluster":                       resourceCluster(),
+			"rancher2_cluster_alert":                 resourceClusterAlert(),
+			"rancher2_cluster_logging":               resourceClusterLogging(),
+			"rancher2_cluster_registration_token":    resourceClusterRegistrationToken(),
+			"rancher2_global_role":                   resourceGlobalRole(),
+			"rancher2_global_role_binding":           resourceGlobalRoleBinding(),
+			"rancher2_multi_cluster_app":             resourceMultiClusterApp(),
+			"rancher2_notifier":                      resourceNotifier(),
+			"rancher2_project":                       resourceProject(),
+			"rancher2_project_alert":                 resourceProjectAlert(),
+			"rancher2_project_logging":               resourceProjectLogging(),
+			"rancher2_project_members":               resourceProjectMembers(),
+			"rancher2_project_role_template_binding": resourceProjectRoleTemplateBinding(),
+			"rancher2_role_template":                 resourceRoleTemplate(),
+			"rancher2_setting":                       resourceSetting(),
+			"rancher2_token":                         resourceToken(),
+			"rancher2_user":                          resourceUser(),
 		},
 		ConfigureFunc: configure,
 	}
@@ -86,9 +117,10 @@ func configure(d *schema.ResourceData) (interface{}, error) {
 	secretKey := d.Get("secret_key").(string)
 	token := d.Get("token").(string)
 	if token != "" {
-		keys := strings.Split(token, ":")
-		accessKey = keys[0]
-		secretKey = keys[1]
+		var err error
+		if accessKey, secretKey, err = parseTokenKey(token); err != nil {
+			return nil, fmt.Errorf("invalid token: %v", err)
+		}
 	}
 	cacert := d.Get("cacert").(string)
 	currentServer := d.Get("current_server").(string)
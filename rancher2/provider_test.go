@@ -28,3 +28,29 @@ func TestReadCLIConfiguration(t *testing.T) {
 		file.Close()
 	}
 }
+
+func TestProvider(t *testing.T) {
+	if err := Provider().InternalValidate(); err != nil {
+		t.Fatalf("provider schema is invalid: %v", err)
+	}
+}
+
+func TestProviderResources(t *testing.T) {
+	resources := Provider().ResourcesMap
+	for _, name := range []string{
+		"rancher2_auth_config_activedirectory",
+		"rancher2_auth_config_adfs",
+		"rancher2_auth_config_azuread",
+		"rancher2_auth_config_freeipa",
+		"rancher2_auth_config_github",
+		"rancher2_auth_config_keycloak",
+		"rancher2_auth_config_local",
+		"rancher2_auth_config_okta",
+		"rancher2_auth_config_openldap",
+		"rancher2_auth_config_ping",
+	} {
+		if _, ok := resources[name]; !ok {
+			t.Errorf("resource %s is not registered", name)
+		}
+	}
+}
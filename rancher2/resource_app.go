@@ -0,0 +1,408 @@
+package rancher2
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/rancher/norman/clientbase"
+	"github.com/rancher/norman/types"
+)
+
+const appType = "app"
+
+// app is the API type of apps deployed from catalogs. The generated project client pulls in half of
+// Kubernetes, so we define the attributes we need on our own, including the values of an app, which are not
+// even known to the version of the Rancher client library we are using.
+type app struct {
+	types.Resource
+	Name                 string            `json:"name,omitempty" yaml:"name,omitempty"`
+	Description          string            `json:"description,omitempty" yaml:"description,omitempty"`
+	ProjectID            string            `json:"projectId,omitempty" yaml:"projectId,omitempty"`
+	TargetNamespace      string            `json:"targetNamespace,omitempty" yaml:"targetNamespace,omitempty"`
+	ExternalID           string            `json:"externalId,omitempty" yaml:"externalId,omitempty"`
+	Answers              map[string]string `json:"answers,omitempty" yaml:"answers,omitempty"`
+	ValuesYaml           string            `json:"valuesYaml,omitempty" yaml:"valuesYaml,omitempty"`
+	AppRevisionID        string            `json:"appRevisionId,omitempty" yaml:"appRevisionId,omitempty"`
+	State                string            `json:"state,omitempty" yaml:"state,omitempty"`
+	Transitioning        string            `json:"transitioning,omitempty" yaml:"transitioning,omitempty"`
+	TransitioningMessage string            `json:"transitioningMessage,omitempty" yaml:"transitioningMessage,omitempty"`
+	UUID                 string            `json:"uuid,omitempty" yaml:"uuid,omitempty"`
+}
+
+// appUpgradeConfig is the input of the "upgrade" action of apps.
+type appUpgradeConfig struct {
+	ExternalID string            `json:"externalId,omitempty" yaml:"externalId,omitempty"`
+	Answers    map[string]string `json:"answers,omitempty" yaml:"answers,omitempty"`
+	ValuesYaml string            `json:"valuesYaml,omitempty" yaml:"valuesYaml,omitempty"`
+}
+
+// appRollbackRevision is the input of the "rollback" action of apps.
+type appRollbackRevision struct {
+	RevisionID string `json:"revisionId,omitempty" yaml:"revisionId,omitempty"`
+}
+
+// appByID returns the app with the given ID if it exists.
+func appByID(c *clientbase.APIBaseClient, id string) (*app, error) {
+	a := &app{}
+	if err := c.ByID(appType, id, a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// appDeployed checks whether the given app has been deployed. It fails if deploying the app failed.
+func appDeployed(a *app) (bool, error) {
+	if a.Transitioning == "error" {
+		return false, fmt.Errorf("app \"%s\" could not be deployed: %s", a.Name, a.TransitioningMessage)
+	}
+	return a.State == "active" && a.Transitioning != "yes", nil
+}
+
+// appDeploymentStarted checks whether deploying the given app has started, given the revision it has been
+// deployed with before (if any). Right after upgrading or rolling back, the app still reports the state of
+// that revision until it is either transitioning or the new revision shows up. An upgrade may also be rejected
+// before a new revision exists, in which case the error is reported on the old revision.
+func appDeploymentStarted(a *app, previousRevisionID string) bool {
+	return previousRevisionID == "" || a.AppRevisionID != previousRevisionID ||
+		a.Transitioning == "yes" || a.Transitioning == "error"
+}
+
+// waitForApp waits until the app with the given ID has been deployed. When upgrading or rolling back an app,
+// the revision it has been deployed with before has to be given. Right after invoking the action, the app
+// still reports the state of that revision, so we wait for the deployment to start first.
+func waitForApp(c *clientbase.APIBaseClient, id string, previousRevisionID string, timeout time.Duration) error {
+	started := false
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"deploying"},
+		Target:  []string{"deployed"},
+		Refresh: func() (interface{}, string, error) {
+			a, err := appByID(c, id)
+			if err != nil {
+				return nil, "", err
+			}
+			started = started || appDeploymentStarted(a, previousRevisionID)
+			if !started {
+				return a, "deploying", nil
+			}
+			deployed, err := appDeployed(a)
+			if err != nil {
+				return nil, "", err
+			}
+			if !deployed {
+				return a, "deploying", nil
+			}
+			return a, "deployed", nil
+		},
+		Timeout:    timeout,
+		Delay:      2 * time.Second,
+		MinTimeout: 2 * time.Second,
+	}
+	_, err := stateConf.WaitForState()
+	return err
+}
+
+// appExternalID returns the external ID of the configured catalog template version.
+func appExternalID(d *schema.ResourceData) string {
+	externalID := &catalogExternalID{
+		CatalogID: d.Get("catalog_id").(string),
+		Template:  d.Get("template_name").(string),
+		Version:   d.Get("template_version").(string),
+	}
+	return externalID.String()
+}
+
+func resourceAppCreate(d *schema.ResourceData, m interface{}) error {
+	projectID := d.Get("project_id").(string)
+	c, err := m.(Config).Project(projectID)
+	if err != nil {
+		return err
+	}
+
+	newApp := &app{}
+	if err := c.Create(appType, &app{
+		Name:            d.Get("name").(string),
+		Description:     d.Get("description").(string),
+		ProjectID:       projectID,
+		TargetNamespace: d.Get("target_namespace").(string),
+		ExternalID:      appExternalID(d),
+		Answers:         expandStringMap(d.Get("answers")),
+		ValuesYaml:      d.Get("values_yaml").(string),
+	}, newApp); err != nil {
+		return err
+	}
+	d.SetId(newApp.ID)
+
+	if err := waitForApp(c, newApp.ID, "", d.Timeout(schema.TimeoutCreate)); err != nil {
+		return err
+	}
+	return resourceAppRead(d, m)
+}
+
+func resourceAppRead(d *schema.ResourceData, m interface{}) error {
+	c, err := m.(Config).Project(d.Get("project_id").(string))
+	if err != nil {
+		return err
+	}
+
+	a, err := appByID(c, d.Id())
+
+	if err != nil {
+		return err
+	} else if a == nil {
+		// If the app DOES NOT EXIST, it has probably already been deleted. Time to update the state...
+		d.SetId("")
+		return nil
+	}
+	d.Set("name", a.Name)
+	d.Set("description", a.Description)
+	d.Set("target_namespace", a.TargetNamespace)
+	d.Set("external_id", a.ExternalID)
+	if externalID, err := parseCatalogExternalID(a.ExternalID); err == nil {
+		d.Set("catalog_id", externalID.CatalogID)
+		d.Set("template_name", externalID.Template)
+		d.Set("template_version", externalID.Version)
+	}
+	d.Set("answers", a.Answers)
+	d.Set("values_yaml", a.ValuesYaml)
+	d.Set("revision_id", a.AppRevisionID)
+	d.Set("state", a.State)
+	d.Set("uuid", a.UUID)
+	return nil
+}
+
+func resourceAppUpdate(d *schema.ResourceData, m interface{}) error {
+	c, err := m.(Config).Project(d.Get("project_id").(string))
+	if err != nil {
+		return err
+	}
+
+	id := d.Id()
+
+	d.Partial(true)
+
+	a, err := appByID(c, id)
+	if err != nil {
+		return err
+	}
+	if a == nil {
+		return fmt.Errorf("app with ID \"%s\" could not be found", id)
+	}
+
+	if d.HasChange("description") {
+		if err := c.Update(appType, &a.Resource, map[string]interface{}{
+			"description": d.Get("description").(string),
+		}, nil); err != nil {
+			return err
+		}
+		d.SetPartial("description")
+	}
+
+	upgrade := false
+	for _, attribute := range []string{"catalog_id", "template_name", "template_version", "answers", "values_yaml"} {
+		upgrade = upgrade || d.HasChange(attribute)
+	}
+	if upgrade {
+		previousRevisionID := a.AppRevisionID
+		if err := c.Action(appType, "upgrade", &a.Resource, &appUpgradeConfig{
+			ExternalID: appExternalID(d),
+			Answers:    expandStringMap(d.Get("answers")),
+			ValuesYaml: d.Get("values_yaml").(string),
+		}, nil); err != nil {
+			return fmt.Errorf("unable to upgrade app \"%s\": %v", a.Name, err)
+		}
+		if upgradeErr := waitForApp(c, id, previousRevisionID, d.Timeout(schema.TimeoutUpdate)); upgradeErr != nil {
+			if !d.Get("rollback_on_failure").(bool) || previousRevisionID == "" {
+				return upgradeErr
+			}
+			if a, err = appByID(c, id); err != nil {
+				return err
+			}
+			if err := c.Action(appType, "rollback", &a.Resource, &appRollbackRevision{
+				RevisionID: previousRevisionID,
+			}, nil); err != nil {
+				return fmt.Errorf("%v (rolling back to revision \"%s\" failed as well: %v)", upgradeErr, previousRevisionID, err)
+			}
+			// If the upgrade failed before a new revision has been created, rolling back does not change the revision.
+			failedRevisionID := a.AppRevisionID
+			if failedRevisionID == previousRevisionID {
+				failedRevisionID = ""
+			}
+			if err := waitForApp(c, id, failedRevisionID, d.Timeout(schema.TimeoutUpdate)); err != nil {
+				return fmt.Errorf("%v (rolling back to revision \"%s\" failed as well: %v)", upgradeErr, previousRevisionID, err)
+			}
+			return fmt.Errorf("%v (rolled back to revision \"%s\")", upgradeErr, previousRevisionID)
+		}
+	}
+
+	d.Partial(false)
+
+	return resourceAppRead(d, m)
+}
+
+func resourceAppDelete(d *schema.ResourceData, m interface{}) error {
+	c, err := m.(Config).Project(d.Get("project_id").(string))
+	if err != nil {
+		return err
+	}
+
+	id := d.Id()
+	a, err := appByID(c, id)
+	if err != nil {
+		return err
+	}
+	if a == nil {
+		// If the app DOES NOT EXIST, it has probably already been deleted. Nothing to do for us here...
+		return nil
+	}
+	if err := c.Delete(&a.Resource); err != nil {
+		return err
+	}
+
+	// Removing an app takes a while, as all of its workloads have to be removed first.
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"removing"},
+		Target:  []string{"removed"},
+		Refresh: func() (interface{}, string, error) {
+			a, err := appByID(c, id)
+			if err != nil {
+				if apiError, isAPIError := err.(*clientbase.APIError); isAPIError && apiError.StatusCode == 404 {
+					return id, "removed", nil
+				}
+				return nil, "", err
+			}
+			return a, "removing", nil
+		},
+		Timeout:    d.Timeout(schema.TimeoutDelete),
+		Delay:      2 * time.Second,
+		MinTimeout: 2 * time.Second,
+	}
+	_, err = stateConf.WaitForState()
+	return err
+}
+
+func resourceAppExists(d *schema.ResourceData, m interface{}) (bool, error) {
+	c, err := m.(Config).Project(d.Get("project_id").(string))
+	if err != nil {
+		return false, err
+	}
+
+	a, err := appByID(c, d.Id())
+	if err != nil {
+		if _, isAPIError := err.(*clientbase.APIError); isAPIError && err.(*clientbase.APIError).StatusCode == 404 {
+			// Ignore 404 errors...
+			return false, nil
+		}
+	}
+	return a != nil, nil
+}
+
+func resourceAppState(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	// Apps are imported by the ID of their project and their own ID (e.g. "c-abcde:p-fghij:my-app").
+	parts := strings.SplitN(d.Id(), ":", 3)
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("invalid ID \"%s\": expected \"<cluster ID>:<project ID>:<app name>\"", d.Id())
+	}
+	d.Set("project_id", parts[0]+":"+parts[1])
+	d.SetId(parts[1] + ":" + parts[2])
+	if err := resourceAppRead(d, m); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceApp() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAppCreate,
+		Read:   resourceAppRead,
+		Update: resourceAppUpdate,
+		Delete: resourceAppDelete,
+		Exists: resourceAppExists,
+		Importer: &schema.ResourceImporter{
+			State: resourceAppState,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Description: "ID of the project the app is deployed to",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"name": {
+				Description: "Name of the app",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"description": {
+				Description: "Description of the app",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"target_namespace": {
+				Description: "Namespace of the project the app is deployed to",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"catalog_id": {
+				Description: "ID of the catalog the app is deployed from (e.g. \"library\" or \"c-abcde:my-catalog\")",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"template_name": {
+				Description: "Name of the catalog template the app is deployed from",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"template_version": {
+				Description: "Version of the catalog template the app is deployed from",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"answers": {
+				Description: "Answers to the questions of the catalog template",
+				Type:        schema.TypeMap,
+				Optional:    true,
+			},
+			"values_yaml": {
+				Description: "Values of the Helm chart in YAML format",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"rollback_on_failure": {
+				Description: "Whether to roll the app back to its previous revision if an upgrade fails",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"external_id": {
+				Description: "External ID of the catalog template version the app is deployed from",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"revision_id": {
+				Description: "ID of the current revision of the app",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"state": {
+				Description: "State of the app as reported by the Rancher API",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"uuid": {
+				Description: "UUID of the app as reported by the Rancher API",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
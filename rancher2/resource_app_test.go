@@ -0,0 +1,53 @@
+package rancher2
+
+import (
+	"testing"
+)
+
+func TestAppDeployed(t *testing.T) {
+	tt := []struct {
+		app         app
+		expected    bool
+		errExpected bool
+	}{
+		{app: app{State: "active"}, expected: true},
+		{app: app{State: "installing", Transitioning: "yes"}, expected: false},
+		{app: app{State: "active", Transitioning: "yes"}, expected: false},
+		{app: app{State: "installing", Transitioning: "error", TransitioningMessage: "failed to install app"}, errExpected: true},
+		{app: app{State: "active", AppRevisionID: "apprevision-abcde", Transitioning: "error", TransitioningMessage: "failed to render template"}, errExpected: true},
+	}
+	for i, td := range tt {
+		deployed, err := appDeployed(&td.app)
+		if (td.errExpected && err == nil) || (!td.errExpected && err != nil) {
+			t.Errorf("unexpected error result for app %d: %v", i, err)
+			continue
+		}
+		if deployed != td.expected {
+			t.Errorf("unexpected result for app %d: %v", i, deployed)
+		}
+	}
+}
+
+func TestAppDeploymentStarted(t *testing.T) {
+	tt := []struct {
+		app                app
+		previousRevisionID string
+		expected           bool
+	}{
+		// Old revision, not transitioning yet
+		{app: app{State: "active", AppRevisionID: "apprevision-abcde"}, previousRevisionID: "apprevision-abcde", expected: false},
+		// Old revision, transitioning
+		{app: app{State: "active", AppRevisionID: "apprevision-abcde", Transitioning: "yes"}, previousRevisionID: "apprevision-abcde", expected: true},
+		// New revision
+		{app: app{State: "active", AppRevisionID: "apprevision-fghij"}, previousRevisionID: "apprevision-abcde", expected: true},
+		// Old revision in error, e.g. because the template could not be rendered
+		{app: app{State: "active", AppRevisionID: "apprevision-abcde", Transitioning: "error"}, previousRevisionID: "apprevision-abcde", expected: true},
+		// Newly created app
+		{app: app{State: "installing"}, expected: true},
+	}
+	for i, td := range tt {
+		if started := appDeploymentStarted(&td.app, td.previousRevisionID); started != td.expected {
+			t.Errorf("unexpected result for app %d: %v", i, started)
+		}
+	}
+}
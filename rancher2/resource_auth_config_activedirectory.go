@@ -0,0 +1,138 @@
+package rancher2
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/rancher/norman/types"
+	"github.com/rancher/types/client/management/v3"
+)
+
+// activeDirectoryAuthConfigID is the ID of the configuration of the Active Directory authentication provider.
+const activeDirectoryAuthConfigID = "activedirectory"
+
+// activeDirectoryConfig extends the generated API type by the resource metadata, which is needed for invoking
+// actions on the configuration.
+type activeDirectoryConfig struct {
+	client.ActiveDirectoryConfig
+	authConfigResource
+}
+
+func (c *activeDirectoryConfig) Status() authConfigStatus {
+	return authConfigStatus{c.AccessMode, c.AllowedPrincipalIDs, c.Enabled}
+}
+
+// expandActiveDirectoryConfig converts the configured settings to their API representation.
+func expandActiveDirectoryConfig(d *schema.ResourceData) *client.ActiveDirectoryConfig {
+	nestedGroupMembershipEnabled := d.Get("nested_group_membership_enabled").(bool)
+	return &client.ActiveDirectoryConfig{
+		Name:                         activeDirectoryAuthConfigID,
+		Type:                         client.ActiveDirectoryConfigType,
+		Enabled:                      true,
+		AccessMode:                   d.Get("access_mode").(string),
+		AllowedPrincipalIDs:          expandStringSet(d.Get("allowed_principal_ids")),
+		Servers:                      expandStringList(d.Get("servers")),
+		Port:                         int64(d.Get("port").(int)),
+		TLS:                          d.Get("tls").(bool),
+		Certificate:                  d.Get("certificate").(string),
+		ConnectionTimeout:            int64(d.Get("connection_timeout").(int)),
+		DefaultLoginDomain:           d.Get("default_login_domain").(string),
+		ServiceAccountUsername:       d.Get("service_account_username").(string),
+		ServiceAccountPassword:       d.Get("service_account_password").(string),
+		UserSearchBase:               d.Get("user_search_base").(string),
+		UserSearchAttribute:          d.Get("user_search_attribute").(string),
+		UserLoginAttribute:           d.Get("user_login_attribute").(string),
+		UserNameAttribute:            d.Get("user_name_attribute").(string),
+		UserObjectClass:              d.Get("user_object_class").(string),
+		UserEnabledAttribute:         d.Get("user_enabled_attribute").(string),
+		UserDisabledBitMask:          int64(d.Get("user_disabled_bit_mask").(int)),
+		GroupSearchBase:              d.Get("group_search_base").(string),
+		GroupSearchAttribute:         d.Get("group_search_attribute").(string),
+		GroupNameAttribute:           d.Get("group_name_attribute").(string),
+		GroupObjectClass:             d.Get("group_object_class").(string),
+		GroupDNAttribute:             d.Get("group_dn_attribute").(string),
+		GroupMemberMappingAttribute:  d.Get("group_member_mapping_attribute").(string),
+		GroupMemberUserAttribute:     d.Get("group_member_user_attribute").(string),
+		NestedGroupMembershipEnabled: &nestedGroupMembershipEnabled,
+	}
+}
+
+// testActiveDirectoryConfig tests the configured settings with the credentials of the test user.
+func testActiveDirectoryConfig(c *client.Client, d *schema.ResourceData, r *types.Resource) error {
+	return c.Action(client.AuthConfigType, "testAndApply", r, &client.ActiveDirectoryTestAndApplyInput{
+		ActiveDirectoryConfig: expandActiveDirectoryConfig(d),
+		Enabled:               true,
+		Username:              d.Get("test_username").(string),
+		Password:              d.Get("test_password").(string),
+	}, nil)
+}
+
+func flattenActiveDirectoryConfig(d *schema.ResourceData, c authConfig) {
+	config := c.(*activeDirectoryConfig)
+	d.Set("servers", config.Servers)
+	d.Set("port", int(config.Port))
+	d.Set("tls", config.TLS)
+	d.Set("certificate", config.Certificate)
+	d.Set("connection_timeout", int(config.ConnectionTimeout))
+	d.Set("default_login_domain", config.DefaultLoginDomain)
+	d.Set("service_account_username", config.ServiceAccountUsername)
+	d.Set("user_search_base", config.UserSearchBase)
+	d.Set("user_search_attribute", config.UserSearchAttribute)
+	d.Set("user_login_attribute", config.UserLoginAttribute)
+	d.Set("user_name_attribute", config.UserNameAttribute)
+	d.Set("user_object_class", config.UserObjectClass)
+	d.Set("user_enabled_attribute", config.UserEnabledAttribute)
+	d.Set("user_disabled_bit_mask", int(config.UserDisabledBitMask))
+	d.Set("group_search_base", config.GroupSearchBase)
+	d.Set("group_search_attribute", config.GroupSearchAttribute)
+	d.Set("group_name_attribute", config.GroupNameAttribute)
+	d.Set("group_object_class", config.GroupObjectClass)
+	d.Set("group_dn_attribute", config.GroupDNAttribute)
+	d.Set("group_member_mapping_attribute", config.GroupMemberMappingAttribute)
+	d.Set("group_member_user_attribute", config.GroupMemberUserAttribute)
+	d.Set("nested_group_membership_enabled", config.NestedGroupMembershipEnabled != nil && *config.NestedGroupMembershipEnabled)
+}
+
+func resourceAuthConfigActiveDirectory() *schema.Resource {
+	p := &authProvider{
+		ID:      activeDirectoryAuthConfigID,
+		Name:    "Active Directory",
+		New:     func() authConfig { return &activeDirectoryConfig{} },
+		Flatten: flattenActiveDirectoryConfig,
+		Test:    testActiveDirectoryConfig,
+		Schema: mergeSchemas(directoryServerSchema(), authConfigTestSchema("Active Directory"), map[string]*schema.Schema{
+			"default_login_domain": {
+				Description: "Domain used for users logging in without specifying one",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"service_account_username": {
+				Description: "Username of the service account used to search users and groups",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"user_search_attribute":  directoryAttributeSchema("Attributes users are searched by, separated by \"|\"", "sAMAccountName|sn|givenName"),
+			"user_login_attribute":   directoryAttributeSchema("Attribute holding the login name of users", "sAMAccountName"),
+			"user_name_attribute":    directoryAttributeSchema("Attribute holding the display name of users", "name"),
+			"user_object_class":      directoryAttributeSchema("Object class of users", "person"),
+			"user_enabled_attribute": directoryAttributeSchema("Attribute holding the account control flags of users", "userAccountControl"),
+			"user_disabled_bit_mask": {
+				Description: "Bit mask of the account control flags that marks users as disabled",
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     2,
+			},
+			"group_search_attribute":         directoryAttributeSchema("Attribute groups are searched by", "sAMAccountName"),
+			"group_name_attribute":           directoryAttributeSchema("Attribute holding the display name of groups", "name"),
+			"group_object_class":             directoryAttributeSchema("Object class of groups", "group"),
+			"group_dn_attribute":             directoryAttributeSchema("Attribute holding the distinguished name of groups", "distinguishedName"),
+			"group_member_mapping_attribute": directoryAttributeSchema("Attribute of groups holding their members", "member"),
+			"group_member_user_attribute":    directoryAttributeSchema("Attribute of users group memberships refer to", "distinguishedName"),
+			"nested_group_membership_enabled": {
+				Description: "Whether to resolve memberships of nested groups",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+		}),
+	}
+	return p.resource()
+}
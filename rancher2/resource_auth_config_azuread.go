@@ -0,0 +1,107 @@
+package rancher2
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/rancher/types/client/management/v3"
+)
+
+// azureADAuthConfigID is the ID of the configuration of the Azure AD authentication provider.
+const azureADAuthConfigID = "azuread"
+
+// azureADConfig extends the generated API type by the resource metadata, which is needed for updating the
+// configuration.
+type azureADConfig struct {
+	client.AzureADConfig
+	authConfigResource
+}
+
+func (c *azureADConfig) Status() authConfigStatus {
+	return authConfigStatus{c.AccessMode, c.AllowedPrincipalIDs, c.Enabled}
+}
+
+// expandAzureADConfig converts the configured settings to their API representation. Testing the configuration
+// requires an interactive OAuth login, so it is enabled as it is.
+func expandAzureADConfig(d *schema.ResourceData) interface{} {
+	return &client.AzureADConfig{
+		Name:                azureADAuthConfigID,
+		Type:                client.AzureADConfigType,
+		Enabled:             true,
+		AccessMode:          d.Get("access_mode").(string),
+		AllowedPrincipalIDs: expandStringSet(d.Get("allowed_principal_ids")),
+		TenantID:            d.Get("tenant_id").(string),
+		ApplicationID:       d.Get("application_id").(string),
+		ApplicationSecret:   d.Get("application_secret").(string),
+		Endpoint:            d.Get("endpoint").(string),
+		GraphEndpoint:       d.Get("graph_endpoint").(string),
+		TokenEndpoint:       d.Get("token_endpoint").(string),
+		AuthEndpoint:        d.Get("auth_endpoint").(string),
+		RancherURL:          d.Get("rancher_url").(string),
+	}
+}
+
+func flattenAzureADConfig(d *schema.ResourceData, c authConfig) {
+	config := c.(*azureADConfig)
+	d.Set("tenant_id", config.TenantID)
+	d.Set("application_id", config.ApplicationID)
+	d.Set("endpoint", config.Endpoint)
+	d.Set("graph_endpoint", config.GraphEndpoint)
+	d.Set("token_endpoint", config.TokenEndpoint)
+	d.Set("auth_endpoint", config.AuthEndpoint)
+	d.Set("rancher_url", config.RancherURL)
+}
+
+func resourceAuthConfigAzureAD() *schema.Resource {
+	p := &authProvider{
+		ID:      azureADAuthConfigID,
+		Name:    "Azure AD",
+		New:     func() authConfig { return &azureADConfig{} },
+		Flatten: flattenAzureADConfig,
+		Expand:  expandAzureADConfig,
+		Schema: map[string]*schema.Schema{
+			"tenant_id": {
+				Description: "ID of the Azure AD tenant",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"application_id": {
+				Description: "ID of the Azure AD application Rancher has been registered as",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"application_secret": {
+				Description: "Secret key of the Azure AD application",
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+			},
+			"endpoint": {
+				Description: "Endpoint of Azure AD",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "https://login.microsoftonline.com/",
+			},
+			"graph_endpoint": {
+				Description: "Endpoint of the Azure AD Graph API",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "https://graph.windows.net/",
+			},
+			"token_endpoint": {
+				Description: "OAuth token endpoint of the tenant",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"auth_endpoint": {
+				Description: "OAuth authorization endpoint of the tenant",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"rancher_url": {
+				Description: "URL Azure AD redirects to after logging in (i.e. the reply URL of the application)",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+		},
+	}
+	return p.resource()
+}
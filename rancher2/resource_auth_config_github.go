@@ -0,0 +1,79 @@
+package rancher2
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/rancher/types/client/management/v3"
+)
+
+// githubAuthConfigID is the ID of the configuration of the GitHub authentication provider.
+const githubAuthConfigID = "github"
+
+// githubConfig extends the generated API type by the resource metadata, which is needed for updating the
+// configuration.
+type githubConfig struct {
+	client.GithubConfig
+	authConfigResource
+}
+
+func (c *githubConfig) Status() authConfigStatus {
+	return authConfigStatus{c.AccessMode, c.AllowedPrincipalIDs, c.Enabled}
+}
+
+// expandGithubConfig converts the configured settings to their API representation. Testing the configuration
+// requires an interactive OAuth login, so it is enabled as it is.
+func expandGithubConfig(d *schema.ResourceData) interface{} {
+	return &client.GithubConfig{
+		Name:                githubAuthConfigID,
+		Type:                client.GithubConfigType,
+		Enabled:             true,
+		AccessMode:          d.Get("access_mode").(string),
+		AllowedPrincipalIDs: expandStringSet(d.Get("allowed_principal_ids")),
+		Hostname:            d.Get("hostname").(string),
+		TLS:                 d.Get("tls").(bool),
+		ClientID:            d.Get("client_id").(string),
+		ClientSecret:        d.Get("client_secret").(string),
+	}
+}
+
+func flattenGithubConfig(d *schema.ResourceData, c authConfig) {
+	config := c.(*githubConfig)
+	d.Set("hostname", config.Hostname)
+	d.Set("tls", config.TLS)
+	d.Set("client_id", config.ClientID)
+}
+
+func resourceAuthConfigGithub() *schema.Resource {
+	p := &authProvider{
+		ID:      githubAuthConfigID,
+		Name:    "GitHub",
+		New:     func() authConfig { return &githubConfig{} },
+		Flatten: flattenGithubConfig,
+		Expand:  expandGithubConfig,
+		Schema: map[string]*schema.Schema{
+			"hostname": {
+				Description: "Host name of the GitHub (Enterprise) server",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "github.com",
+			},
+			"tls": {
+				Description: "Whether to connect to the GitHub server via TLS",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"client_id": {
+				Description: "Client ID of the GitHub OAuth application",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"client_secret": {
+				Description: "Client secret of the GitHub OAuth application",
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+			},
+		},
+	}
+	return p.resource()
+}
@@ -0,0 +1,199 @@
+package rancher2
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/rancher/norman/types"
+	"github.com/rancher/types/client/management/v3"
+)
+
+// IDs of the configurations of the LDAP-based authentication providers.
+const (
+	openLdapAuthConfigID = "openldap"
+	freeIpaAuthConfigID  = "freeipa"
+)
+
+// ldapConfig extends the generated API type by the resource metadata, which is needed for invoking actions on
+// the configuration. OpenLDAP and FreeIPA have distinct API types, but the settings of FreeIPA are a subset of
+// the ones of OpenLDAP, so we read the configurations of both into the OpenLDAP type.
+type ldapConfig struct {
+	client.OpenLdapConfig
+	authConfigResource
+}
+
+func (c *ldapConfig) Status() authConfigStatus {
+	return authConfigStatus{c.AccessMode, c.AllowedPrincipalIDs, c.Enabled}
+}
+
+// expandLdapConfig converts the configured settings of an LDAP-based provider to their API representation,
+// which OpenLDAP and FreeIPA share for testing.
+func expandLdapConfig(d *schema.ResourceData, id string, configType string) *client.LdapConfig {
+	config := &client.LdapConfig{
+		Name:                            id,
+		Type:                            configType,
+		Enabled:                         true,
+		AccessMode:                      d.Get("access_mode").(string),
+		AllowedPrincipalIDs:             expandStringSet(d.Get("allowed_principal_ids")),
+		Servers:                         expandStringList(d.Get("servers")),
+		Port:                            int64(d.Get("port").(int)),
+		TLS:                             d.Get("tls").(bool),
+		Certificate:                     d.Get("certificate").(string),
+		ConnectionTimeout:               int64(d.Get("connection_timeout").(int)),
+		ServiceAccountDistinguishedName: d.Get("service_account_distinguished_name").(string),
+		ServiceAccountPassword:          d.Get("service_account_password").(string),
+		UserSearchBase:                  d.Get("user_search_base").(string),
+		UserSearchAttribute:             d.Get("user_search_attribute").(string),
+		UserLoginAttribute:              d.Get("user_login_attribute").(string),
+		UserNameAttribute:               d.Get("user_name_attribute").(string),
+		UserObjectClass:                 d.Get("user_object_class").(string),
+		UserMemberAttribute:             d.Get("user_member_attribute").(string),
+		UserEnabledAttribute:            d.Get("user_enabled_attribute").(string),
+		UserDisabledBitMask:             int64(d.Get("user_disabled_bit_mask").(int)),
+		GroupSearchBase:                 d.Get("group_search_base").(string),
+		GroupSearchAttribute:            d.Get("group_search_attribute").(string),
+		GroupNameAttribute:              d.Get("group_name_attribute").(string),
+		GroupObjectClass:                d.Get("group_object_class").(string),
+		GroupDNAttribute:                d.Get("group_dn_attribute").(string),
+		GroupMemberMappingAttribute:     d.Get("group_member_mapping_attribute").(string),
+		GroupMemberUserAttribute:        d.Get("group_member_user_attribute").(string),
+	}
+	if v, ok := d.GetOk("nested_group_membership_enabled"); ok {
+		config.NestedGroupMembershipEnabled = v.(bool)
+	}
+	return config
+}
+
+// testLdapConfig returns a function testing the configured settings of an LDAP-based provider with the
+// credentials of the test user.
+func testLdapConfig(id string, configType string) func(*client.Client, *schema.ResourceData, *types.Resource) error {
+	return func(c *client.Client, d *schema.ResourceData, r *types.Resource) error {
+		return c.Action(client.AuthConfigType, "testAndApply", r, &client.OpenLdapTestAndApplyInput{
+			LdapConfig: expandLdapConfig(d, id, configType),
+			Username:   d.Get("test_username").(string),
+			Password:   d.Get("test_password").(string),
+		}, nil)
+	}
+}
+
+// flattenLdapConfig updates the terraform state with the settings shared by the LDAP-based providers.
+func flattenLdapConfig(d *schema.ResourceData, c authConfig) {
+	config := c.(*ldapConfig)
+	d.Set("servers", config.Servers)
+	d.Set("port", int(config.Port))
+	d.Set("tls", config.TLS)
+	d.Set("certificate", config.Certificate)
+	d.Set("connection_timeout", int(config.ConnectionTimeout))
+	d.Set("service_account_distinguished_name", config.ServiceAccountDistinguishedName)
+	d.Set("user_search_base", config.UserSearchBase)
+	d.Set("user_search_attribute", config.UserSearchAttribute)
+	d.Set("user_login_attribute", config.UserLoginAttribute)
+	d.Set("user_name_attribute", config.UserNameAttribute)
+	d.Set("user_object_class", config.UserObjectClass)
+	d.Set("user_member_attribute", config.UserMemberAttribute)
+	d.Set("user_enabled_attribute", config.UserEnabledAttribute)
+	d.Set("user_disabled_bit_mask", int(config.UserDisabledBitMask))
+	d.Set("group_search_base", config.GroupSearchBase)
+	d.Set("group_search_attribute", config.GroupSearchAttribute)
+	d.Set("group_name_attribute", config.GroupNameAttribute)
+	d.Set("group_object_class", config.GroupObjectClass)
+	d.Set("group_dn_attribute", config.GroupDNAttribute)
+	d.Set("group_member_mapping_attribute", config.GroupMemberMappingAttribute)
+	d.Set("group_member_user_attribute", config.GroupMemberUserAttribute)
+}
+
+// flattenOpenLdapConfig updates the terraform state with the settings of OpenLDAP, which in contrast to FreeIPA
+// is able to resolve nested group memberships.
+func flattenOpenLdapConfig(d *schema.ResourceData, c authConfig) {
+	flattenLdapConfig(d, c)
+	d.Set("nested_group_membership_enabled", c.(*ldapConfig).NestedGroupMembershipEnabled)
+}
+
+// ldapConfigSchema returns the schema of the settings shared by the LDAP-based providers. The defaults of
+// the attributes depend on the directory schema of the provider.
+func ldapConfigSchema(provider string, defaults map[string]string) map[string]*schema.Schema {
+	s := mergeSchemas(directoryServerSchema(), authConfigTestSchema(provider), map[string]*schema.Schema{
+		"service_account_distinguished_name": {
+			Description: "Distinguished name of the service account used to search users and groups",
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+		"user_disabled_bit_mask": {
+			Description: "Bit mask of the user enabled attribute that marks users as disabled",
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Default:     0,
+		},
+	})
+	descriptions := map[string]string{
+		"user_search_attribute":          "Attributes users are searched by, separated by \"|\"",
+		"user_login_attribute":           "Attribute holding the login name of users",
+		"user_name_attribute":            "Attribute holding the display name of users",
+		"user_object_class":              "Object class of users",
+		"user_member_attribute":          "Attribute of users holding their group memberships",
+		"user_enabled_attribute":         "Attribute holding whether users are enabled",
+		"group_search_attribute":         "Attribute groups are searched by",
+		"group_name_attribute":           "Attribute holding the display name of groups",
+		"group_object_class":             "Object class of groups",
+		"group_dn_attribute":             "Attribute holding the distinguished name of groups",
+		"group_member_mapping_attribute": "Attribute of groups holding their members",
+		"group_member_user_attribute":    "Attribute of users group memberships refer to",
+	}
+	for k, description := range descriptions {
+		s[k] = directoryAttributeSchema(description, defaults[k])
+	}
+	return s
+}
+
+func resourceAuthConfigOpenLdap() *schema.Resource {
+	s := ldapConfigSchema("OpenLDAP", map[string]string{
+		"user_search_attribute":          "uid|sn|givenName",
+		"user_login_attribute":           "uid",
+		"user_name_attribute":            "cn",
+		"user_object_class":              "inetOrgPerson",
+		"user_member_attribute":          "memberOf",
+		"group_search_attribute":         "cn",
+		"group_name_attribute":           "cn",
+		"group_object_class":             "groupOfNames",
+		"group_dn_attribute":             "entryDN",
+		"group_member_mapping_attribute": "member",
+		"group_member_user_attribute":    "entryDN",
+	})
+	s["nested_group_membership_enabled"] = &schema.Schema{
+		Description: "Whether to resolve memberships of nested groups",
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+	}
+	p := &authProvider{
+		ID:      openLdapAuthConfigID,
+		Name:    "OpenLDAP",
+		New:     func() authConfig { return &ldapConfig{} },
+		Flatten: flattenOpenLdapConfig,
+		Test:    testLdapConfig(openLdapAuthConfigID, client.OpenLdapConfigType),
+		Schema:  s,
+	}
+	return p.resource()
+}
+
+func resourceAuthConfigFreeIpa() *schema.Resource {
+	p := &authProvider{
+		ID:      freeIpaAuthConfigID,
+		Name:    "FreeIPA",
+		New:     func() authConfig { return &ldapConfig{} },
+		Flatten: flattenLdapConfig,
+		Test:    testLdapConfig(freeIpaAuthConfigID, client.FreeIpaConfigType),
+		Schema: ldapConfigSchema("FreeIPA", map[string]string{
+			"user_search_attribute":          "uid|sn|givenName",
+			"user_login_attribute":           "uid",
+			"user_name_attribute":            "givenName",
+			"user_object_class":              "inetorgperson",
+			"user_member_attribute":          "memberOf",
+			"group_search_attribute":         "cn",
+			"group_name_attribute":           "cn",
+			"group_object_class":             "groupofnames",
+			"group_dn_attribute":             "entrydn",
+			"group_member_mapping_attribute": "member",
+			"group_member_user_attribute":    "entrydn",
+		}),
+	}
+	return p.resource()
+}
@@ -0,0 +1,85 @@
+package rancher2
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/rancher/norman/clientbase"
+	"github.com/rancher/types/client/management/v3"
+)
+
+// localAuthConfigID is the ID of the configuration of the local authentication provider.
+const localAuthConfigID = "local"
+
+// localConfig extends the generated API type by the resource metadata, which is needed for updating the
+// configuration.
+type localConfig struct {
+	client.LocalConfig
+	authConfigResource
+}
+
+func (c *localConfig) Status() authConfigStatus {
+	return authConfigStatus{c.AccessMode, c.AllowedPrincipalIDs, c.Enabled}
+}
+
+// validateLocalConfig makes sure that all allowed principals exist. Whether the user the provider is
+// authenticated as is still allowed to log in afterwards is checked for all providers when applying.
+func validateLocalConfig(c *client.Client, d *schema.ResourceData) error {
+	for _, id := range expandStringSet(d.Get("allowed_principal_ids")) {
+		if _, err := c.Principal.ByID(url.PathEscape(id)); err != nil {
+			if apiError, isAPIError := err.(*clientbase.APIError); isAPIError && apiError.StatusCode == 404 {
+				return fmt.Errorf("allowed principal \"%s\" does not exist", id)
+			}
+			return fmt.Errorf("unable to look up allowed principal \"%s\": %v", id, err)
+		}
+	}
+	return nil
+}
+
+func expandLocalConfig(d *schema.ResourceData) interface{} {
+	return map[string]interface{}{
+		client.LocalConfigFieldAccessMode:          d.Get("access_mode").(string),
+		client.LocalConfigFieldAllowedPrincipalIDs: expandStringSet(d.Get("allowed_principal_ids")),
+	}
+}
+
+func resourceAuthConfigLocalDelete(d *schema.ResourceData, m interface{}) error {
+	rancher := m.(Config).Rancher()
+
+	// The local provider cannot be disabled, so we lift all access restrictions instead.
+	config := &localConfig{}
+	if err := authConfigByID(rancher, localAuthConfigID, config); err != nil {
+		return err
+	}
+	return rancher.Update(client.AuthConfigType, config.Resource(), map[string]interface{}{
+		client.LocalConfigFieldAccessMode:          authAccessModeUnrestricted,
+		client.LocalConfigFieldAllowedPrincipalIDs: []string{},
+	}, nil)
+}
+
+func resourceAuthConfigLocal() *schema.Resource {
+	p := &authProvider{
+		ID:      localAuthConfigID,
+		Name:    "local",
+		New:     func() authConfig { return &localConfig{} },
+		Flatten: func(*schema.ResourceData, authConfig) {},
+		Expand:  expandLocalConfig,
+		Schema:  map[string]*schema.Schema{},
+	}
+	r := p.resource()
+	r.Create = func(d *schema.ResourceData, m interface{}) error {
+		if err := validateLocalConfig(m.(Config).Rancher(), d); err != nil {
+			return err
+		}
+		return p.create(d, m)
+	}
+	r.Update = func(d *schema.ResourceData, m interface{}) error {
+		if err := validateLocalConfig(m.(Config).Rancher(), d); err != nil {
+			return err
+		}
+		return p.update(d, m)
+	}
+	r.Delete = resourceAuthConfigLocalDelete
+	return r
+}
@@ -0,0 +1,132 @@
+package rancher2
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/rancher/types/client/management/v3"
+)
+
+// IDs of the configurations of the SAML-based authentication providers.
+const (
+	pingAuthConfigID     = "ping"
+	adfsAuthConfigID     = "adfs"
+	keycloakAuthConfigID = "keycloak"
+	oktaAuthConfigID     = "okta"
+)
+
+// oktaConfigType is the API type of the Okta configuration, which is not known to our client library.
+const oktaConfigType = "oktaConfig"
+
+// samlConfig is the configuration of a SAML-based authentication provider. All of them share the attributes
+// of the Ping configuration; Okta is not even known to the version of the Rancher client library we are using.
+type samlConfig struct {
+	client.PingConfig
+	authConfigResource
+}
+
+func (c *samlConfig) Status() authConfigStatus {
+	return authConfigStatus{c.AccessMode, c.AllowedPrincipalIDs, c.Enabled}
+}
+
+// expandSamlConfig returns a function converting the configured settings of a SAML-based provider to their
+// API representation. Testing the configuration requires an interactive login, so it is enabled as it is.
+func expandSamlConfig(id string, configType string) func(*schema.ResourceData) interface{} {
+	return func(d *schema.ResourceData) interface{} {
+		return &client.PingConfig{
+			Name:                id,
+			Type:                configType,
+			Enabled:             true,
+			AccessMode:          d.Get("access_mode").(string),
+			AllowedPrincipalIDs: expandStringSet(d.Get("allowed_principal_ids")),
+			RancherAPIHost:      d.Get("rancher_api_host").(string),
+			IDPMetadataContent:  d.Get("idp_metadata_content").(string),
+			SpCert:              d.Get("sp_cert").(string),
+			SpKey:               d.Get("sp_key").(string),
+			DisplayNameField:    d.Get("display_name_field").(string),
+			UserNameField:       d.Get("user_name_field").(string),
+			UIDField:            d.Get("uid_field").(string),
+			GroupsField:         d.Get("groups_field").(string),
+		}
+	}
+}
+
+func flattenSamlConfig(d *schema.ResourceData, c authConfig) {
+	config := c.(*samlConfig)
+	d.Set("rancher_api_host", config.RancherAPIHost)
+	d.Set("idp_metadata_content", config.IDPMetadataContent)
+	d.Set("sp_cert", config.SpCert)
+	d.Set("display_name_field", config.DisplayNameField)
+	d.Set("user_name_field", config.UserNameField)
+	d.Set("uid_field", config.UIDField)
+	d.Set("groups_field", config.GroupsField)
+}
+
+// resourceAuthConfigSaml returns the resource managing the configuration of the given SAML-based provider.
+func resourceAuthConfigSaml(id string, name string, configType string) *schema.Resource {
+	p := &authProvider{
+		ID:      id,
+		Name:    name,
+		New:     func() authConfig { return &samlConfig{} },
+		Flatten: flattenSamlConfig,
+		Expand:  expandSamlConfig(id, configType),
+		Schema: map[string]*schema.Schema{
+			"rancher_api_host": {
+				Description: "URL of the Rancher API the identity provider redirects to",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"idp_metadata_content": {
+				Description: "SAML metadata of the identity provider (XML)",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"sp_cert": {
+				Description: "PEM-encoded certificate of Rancher as service provider",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"sp_key": {
+				Description: "PEM-encoded private key of Rancher as service provider",
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+			},
+			"display_name_field": {
+				Description: "SAML attribute holding the display name of users",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"user_name_field": {
+				Description: "SAML attribute holding the login name of users",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"uid_field": {
+				Description: "SAML attribute holding the unique ID of users",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"groups_field": {
+				Description: "SAML attribute holding the group memberships of users",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+		},
+	}
+	return p.resource()
+}
+
+func resourceAuthConfigPing() *schema.Resource {
+	return resourceAuthConfigSaml(pingAuthConfigID, "Ping", client.PingConfigType)
+}
+
+func resourceAuthConfigADFS() *schema.Resource {
+	return resourceAuthConfigSaml(adfsAuthConfigID, "ADFS", client.ADFSConfigType)
+}
+
+func resourceAuthConfigKeycloak() *schema.Resource {
+	return resourceAuthConfigSaml(keycloakAuthConfigID, "Keycloak", client.KeyCloakConfigType)
+}
+
+func resourceAuthConfigOkta() *schema.Resource {
+	return resourceAuthConfigSaml(oktaAuthConfigID, "Okta", oktaConfigType)
+}
@@ -0,0 +1,370 @@
+package rancher2
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/rancher/norman/clientbase"
+	"github.com/rancher/types/client/management/v3"
+)
+
+// Scopes of catalogs, which determine who is able to deploy their templates.
+const (
+	catalogScopeGlobal  = "global"
+	catalogScopeCluster = "cluster"
+	catalogScopeProject = "project"
+)
+
+// API types of catalogs for each scope. Cluster and project catalogs are not known to the version of the
+// Rancher client library we are using.
+var catalogTypes = map[string]string{
+	catalogScopeGlobal:  client.CatalogType,
+	catalogScopeCluster: "clusterCatalog",
+	catalogScopeProject: "projectCatalog",
+}
+
+// catalogConditionRefreshed is the condition reporting whether a catalog has been indexed.
+const catalogConditionRefreshed = "Refreshed"
+
+// catalog extends the generated API type by the attributes of catalogs that are not known to the version of
+// the Rancher client library we are using: the cluster or project they are scoped to and their credentials.
+type catalog struct {
+	client.Catalog
+	ClusterID string `json:"clusterId,omitempty" yaml:"clusterId,omitempty"`
+	ProjectID string `json:"projectId,omitempty" yaml:"projectId,omitempty"`
+	Username  string `json:"username,omitempty" yaml:"username,omitempty"`
+	Password  string `json:"password,omitempty" yaml:"password,omitempty"`
+}
+
+// catalogScopeByID derives the scope of a catalog from its ID. Cluster and project catalogs live in the
+// namespace of their cluster or project (e.g. "c-abcde:my-catalog" or "p-fghij:my-catalog").
+func catalogScopeByID(id string) string {
+	parts := strings.SplitN(id, ":", 2)
+	if len(parts) < 2 {
+		return catalogScopeGlobal
+	}
+	if strings.HasPrefix(parts[0], "p-") {
+		return catalogScopeProject
+	}
+	return catalogScopeCluster
+}
+
+// catalogByID returns the catalog with the given ID if it exists.
+func catalogByID(c *client.Client, id string) (*catalog, error) {
+	cat := &catalog{}
+	if err := c.ByID(catalogTypes[catalogScopeByID(id)], id, cat); err != nil {
+		return nil, err
+	}
+	return cat, nil
+}
+
+// catalogRefreshed checks whether the given catalog has been indexed. It fails if indexing the catalog failed.
+func catalogRefreshed(cat *catalog) (bool, error) {
+	for _, condition := range cat.Conditions {
+		if condition.Type != catalogConditionRefreshed {
+			continue
+		}
+		if condition.Status == "False" && condition.Message != "" {
+			return false, fmt.Errorf("catalog \"%s\" could not be refreshed: %s", cat.Name, condition.Message)
+		}
+		if condition.Status != "True" {
+			return false, nil
+		}
+	}
+	if cat.Transitioning == "error" {
+		return false, fmt.Errorf("catalog \"%s\" could not be refreshed: %s", cat.Name, cat.TransitioningMessage)
+	}
+	return cat.State == "active" && cat.Transitioning != "yes", nil
+}
+
+// waitForCatalog waits until the catalog with the given ID has been indexed. If the catalog has been
+// refreshed explicitly, we also wait until it reports a refresh that is newer than the given one.
+func waitForCatalog(c *client.Client, id string, lastRefresh string, timeout time.Duration) error {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"refreshing"},
+		Target:  []string{"refreshed"},
+		Refresh: func() (interface{}, string, error) {
+			cat, err := catalogByID(c, id)
+			if err != nil {
+				return nil, "", err
+			}
+			refreshed, err := catalogRefreshed(cat)
+			if err != nil {
+				return nil, "", err
+			}
+			if !refreshed || cat.LastRefreshTimestamp == lastRefresh {
+				return cat, "refreshing", nil
+			}
+			return cat, "refreshed", nil
+		},
+		Timeout:    timeout,
+		Delay:      2 * time.Second,
+		MinTimeout: 2 * time.Second,
+	}
+	_, err := stateConf.WaitForState()
+	return err
+}
+
+func resourceCatalogCreate(d *schema.ResourceData, m interface{}) error {
+	rancher := m.(Config).Rancher()
+
+	scope := d.Get("scope").(string)
+	cat := &catalog{
+		Catalog: client.Catalog{
+			Name:        d.Get("name").(string),
+			Description: d.Get("description").(string),
+			URL:         d.Get("url").(string),
+			Branch:      d.Get("branch").(string),
+			Kind:        d.Get("kind").(string),
+		},
+		Username: d.Get("username").(string),
+		Password: d.Get("password").(string),
+	}
+	switch scope {
+	case catalogScopeCluster:
+		if cat.ClusterID = d.Get("cluster_id").(string); cat.ClusterID == "" {
+			return fmt.Errorf("cluster_id is required for catalogs of scope \"%s\"", scope)
+		}
+	case catalogScopeProject:
+		if cat.ProjectID = d.Get("project_id").(string); cat.ProjectID == "" {
+			return fmt.Errorf("project_id is required for catalogs of scope \"%s\"", scope)
+		}
+	}
+
+	newCatalog := &catalog{}
+	if err := rancher.Create(catalogTypes[scope], cat, newCatalog); err != nil {
+		return err
+	}
+	d.SetId(newCatalog.ID)
+
+	if err := waitForCatalog(rancher, newCatalog.ID, "", d.Timeout(schema.TimeoutCreate)); err != nil {
+		return err
+	}
+	return resourceCatalogRead(d, m)
+}
+
+func resourceCatalogRead(d *schema.ResourceData, m interface{}) error {
+	rancher := m.(Config).Rancher()
+
+	cat, err := catalogByID(rancher, d.Id())
+
+	if err != nil {
+		return err
+	} else if cat == nil {
+		// If the catalog DOES NOT EXIST, it has probably already been deleted. Time to update the state...
+		d.SetId("")
+		return nil
+	}
+	d.Set("name", cat.Name)
+	d.Set("scope", catalogScopeByID(cat.ID))
+	d.Set("cluster_id", cat.ClusterID)
+	d.Set("project_id", cat.ProjectID)
+	d.Set("description", cat.Description)
+	d.Set("url", cat.URL)
+	d.Set("branch", cat.Branch)
+	d.Set("kind", cat.Kind)
+	d.Set("username", cat.Username)
+	// Rancher never reports the password, so we keep the configured one.
+	d.Set("state", cat.State)
+	d.Set("commit", cat.Commit)
+	d.Set("last_refresh_timestamp", cat.LastRefreshTimestamp)
+	d.Set("uuid", cat.UUID)
+	return nil
+}
+
+func resourceCatalogUpdate(d *schema.ResourceData, m interface{}) error {
+	rancher := m.(Config).Rancher()
+
+	id := d.Id()
+
+	d.Partial(true)
+
+	cat, err := catalogByID(rancher, id)
+	if err != nil {
+		return err
+	}
+	if cat == nil {
+		return fmt.Errorf("catalog with ID \"%s\" could not be found", id)
+	}
+	catalogType := catalogTypes[catalogScopeByID(id)]
+
+	updates := map[string]interface{}{}
+	for attribute, field := range map[string]string{
+		"description": "description",
+		"url":         "url",
+		"branch":      "branch",
+		"kind":        "kind",
+		"username":    "username",
+		"password":    "password",
+	} {
+		if d.HasChange(attribute) {
+			updates[field] = d.Get(attribute).(string)
+		}
+	}
+	if len(updates) > 0 {
+		if err := rancher.Update(catalogType, &cat.Resource, updates, nil); err != nil {
+			return err
+		}
+	}
+
+	// Changing the source of a catalog makes Rancher index it again, otherwise we have to trigger it.
+	if d.HasChange("refresh") {
+		if err := rancher.Action(catalogType, "refresh", &cat.Resource, nil, nil); err != nil {
+			return fmt.Errorf("unable to refresh catalog \"%s\": %v", cat.Name, err)
+		}
+	}
+	if d.HasChange("url") || d.HasChange("branch") || d.HasChange("kind") || d.HasChange("username") || d.HasChange("password") || d.HasChange("refresh") {
+		if err := waitForCatalog(rancher, id, cat.LastRefreshTimestamp, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return err
+		}
+	}
+
+	d.Partial(false)
+
+	return resourceCatalogRead(d, m)
+}
+
+func resourceCatalogDelete(d *schema.ResourceData, m interface{}) error {
+	rancher := m.(Config).Rancher()
+
+	id := d.Id()
+	cat, err := catalogByID(rancher, id)
+	if err != nil {
+		return err
+	}
+	if cat == nil {
+		// If the catalog DOES NOT EXIST, it has probably already been deleted. Nothing to do for us here...
+		return nil
+	}
+	return rancher.Ops.DoResourceDelete(catalogTypes[catalogScopeByID(id)], &cat.Resource)
+}
+
+func resourceCatalogExists(d *schema.ResourceData, m interface{}) (bool, error) {
+	rancher := m.(Config).Rancher()
+
+	cat, err := catalogByID(rancher, d.Id())
+	if err != nil {
+		if _, isAPIError := err.(*clientbase.APIError); isAPIError && err.(*clientbase.APIError).StatusCode == 404 {
+			// Ignore 404 errors...
+			return false, nil
+		}
+	}
+	return cat != nil, nil
+}
+
+func resourceCatalogState(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if err := resourceCatalogRead(d, m); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceCatalog() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCatalogCreate,
+		Read:   resourceCatalogRead,
+		Update: resourceCatalogUpdate,
+		Delete: resourceCatalogDelete,
+		Exists: resourceCatalogExists,
+		Importer: &schema.ResourceImporter{
+			State: resourceCatalogState,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "Name of the catalog",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"scope": {
+				Description:  "Scope of the catalog (\"global\", \"cluster\" or \"project\")",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      catalogScopeGlobal,
+				ValidateFunc: validation.StringInSlice([]string{catalogScopeGlobal, catalogScopeCluster, catalogScopeProject}, false),
+			},
+			"cluster_id": {
+				Description:   "ID of the cluster a catalog of scope \"cluster\" belongs to",
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"project_id"},
+			},
+			"project_id": {
+				Description:   "ID of the project a catalog of scope \"project\" belongs to",
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"cluster_id"},
+			},
+			"description": {
+				Description: "Description of the catalog",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"url": {
+				Description: "URL of the repository of the catalog",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"branch": {
+				Description: "Branch of the repository (only used by catalogs of kind \"git\")",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "master",
+			},
+			"kind": {
+				Description:  "Kind of the repository (\"helm\" or \"git\")",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "helm",
+				ValidateFunc: validation.StringInSlice([]string{"helm", "git"}, false),
+			},
+			"username": {
+				Description: "Username used to access the repository",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"password": {
+				Description: "Password used to access the repository",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"refresh": {
+				Description: "Arbitrary values which trigger a refresh of the catalog when changed",
+				Type:        schema.TypeMap,
+				Optional:    true,
+			},
+			"state": {
+				Description: "State of the catalog as reported by the Rancher API",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"commit": {
+				Description: "Commit of the repository the catalog has been indexed at",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"last_refresh_timestamp": {
+				Description: "Date the catalog has been indexed for the last time",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"uuid": {
+				Description: "UUID of the catalog as reported by the Rancher API",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
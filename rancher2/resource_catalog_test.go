@@ -0,0 +1,54 @@
+package rancher2
+
+import (
+	"testing"
+
+	"github.com/rancher/types/client/management/v3"
+)
+
+func TestCatalogScopeByID(t *testing.T) {
+	tt := []struct {
+		id       string
+		expected string
+	}{
+		{id: "library", expected: catalogScopeGlobal},
+		{id: "c-abcde:internal-charts", expected: catalogScopeCluster},
+		{id: "p-fghij:internal-charts", expected: catalogScopeProject},
+	}
+	for _, td := range tt {
+		if scope := catalogScopeByID(td.id); scope != td.expected {
+			t.Errorf("unexpected scope of catalog \"%s\": %s", td.id, scope)
+		}
+	}
+}
+
+func TestCatalogRefreshed(t *testing.T) {
+	tt := []struct {
+		catalog     client.Catalog
+		expected    bool
+		errExpected bool
+	}{
+		{catalog: client.Catalog{State: "active"}, expected: true},
+		{catalog: client.Catalog{State: "refreshing", Transitioning: "yes"}, expected: false},
+		{catalog: client.Catalog{State: "active", Conditions: []client.CatalogCondition{
+			{Type: catalogConditionRefreshed, Status: "Unknown"},
+		}}, expected: false},
+		{catalog: client.Catalog{State: "active", Conditions: []client.CatalogCondition{
+			{Type: catalogConditionRefreshed, Status: "True"},
+		}}, expected: true},
+		{catalog: client.Catalog{State: "active", Conditions: []client.CatalogCondition{
+			{Type: catalogConditionRefreshed, Status: "False", Message: "repository not found"},
+		}}, errExpected: true},
+		{catalog: client.Catalog{State: "error", Transitioning: "error", TransitioningMessage: "authentication failed"}, errExpected: true},
+	}
+	for i, td := range tt {
+		refreshed, err := catalogRefreshed(&catalog{Catalog: td.catalog})
+		if (td.errExpected && err == nil) || (!td.errExpected && err != nil) {
+			t.Errorf("unexpected error result for catalog %d: %v", i, err)
+			continue
+		}
+		if refreshed != td.expected {
+			t.Errorf("unexpected result for catalog %d: %v", i, refreshed)
+		}
+	}
+}
@@ -0,0 +1,297 @@
+package rancher2
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/rancher/norman/clientbase"
+	"github.com/rancher/types/client/management/v3"
+)
+
+// clusterAlertTargets lists the attributes defining what a cluster alert watches. Exactly one of them has to
+// be given.
+var clusterAlertTargets = []string{"system_service", "node", "node_selector", "event"}
+
+// expandClusterAlert converts the configured cluster alert to its API representation.
+func expandClusterAlert(c *client.Client, d *schema.ResourceData) (*client.ClusterAlert, error) {
+	recipients, err := expandAlertRecipients(c, d.Get("recipient"))
+	if err != nil {
+		return nil, err
+	}
+	alert := &client.ClusterAlert{
+		ClusterID:             d.Get("cluster_id").(string),
+		Name:                  d.Get("name").(string),
+		Description:           d.Get("description").(string),
+		Severity:              d.Get("severity").(string),
+		InitialWaitSeconds:    int64(d.Get("initial_wait_seconds").(int)),
+		RepeatIntervalSeconds: int64(d.Get("repeat_interval_seconds").(int)),
+		Recipients:            recipients,
+	}
+
+	target, config, err := alertTarget(d, clusterAlertTargets)
+	if err != nil {
+		return nil, err
+	}
+	switch target {
+	case "system_service":
+		alert.TargetSystemService = &client.TargetSystemService{
+			Condition: config["condition"].(string),
+		}
+	case "node":
+		alert.TargetNode = &client.TargetNode{
+			NodeID:       config["node_id"].(string),
+			Condition:    config["condition"].(string),
+			CPUThreshold: int64(config["cpu_threshold"].(int)),
+			MemThreshold: int64(config["mem_threshold"].(int)),
+		}
+	case "node_selector":
+		alert.TargetNode = &client.TargetNode{
+			Selector:     expandStringMap(config["selector"]),
+			Condition:    config["condition"].(string),
+			CPUThreshold: int64(config["cpu_threshold"].(int)),
+			MemThreshold: int64(config["mem_threshold"].(int)),
+		}
+	case "event":
+		alert.TargetEvent = &client.TargetEvent{
+			EventType:    config["event_type"].(string),
+			ResourceKind: config["resource_kind"].(string),
+		}
+	}
+	return alert, nil
+}
+
+// flattenClusterAlertTargets updates the terraform state with the target of the given cluster alert. Nodes
+// are either targeted by their ID or by a selector, which we report as distinct targets.
+func flattenClusterAlertTargets(d *schema.ResourceData, alert *client.ClusterAlert) error {
+	targets := map[string][]interface{}{}
+	for _, k := range clusterAlertTargets {
+		targets[k] = []interface{}{}
+	}
+	if t := alert.TargetSystemService; t != nil {
+		targets["system_service"] = []interface{}{map[string]interface{}{
+			"condition": t.Condition,
+		}}
+	}
+	if t := alert.TargetNode; t != nil {
+		target := map[string]interface{}{
+			"condition":     t.Condition,
+			"cpu_threshold": int(t.CPUThreshold),
+			"mem_threshold": int(t.MemThreshold),
+		}
+		if t.NodeID != "" {
+			target["node_id"] = t.NodeID
+			targets["node"] = []interface{}{target}
+		} else {
+			target["selector"] = t.Selector
+			targets["node_selector"] = []interface{}{target}
+		}
+	}
+	if t := alert.TargetEvent; t != nil {
+		targets["event"] = []interface{}{map[string]interface{}{
+			"event_type":    t.EventType,
+			"resource_kind": t.ResourceKind,
+		}}
+	}
+	for k, v := range targets {
+		if err := d.Set(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func resourceClusterAlertCreate(d *schema.ResourceData, m interface{}) error {
+	rancher := m.(Config).Rancher()
+
+	alert, err := expandClusterAlert(rancher, d)
+	if err != nil {
+		return err
+	}
+	newAlert, err := rancher.ClusterAlert.Create(alert)
+	if err != nil {
+		return err
+	}
+	d.SetId(newAlert.ID)
+
+	return resourceClusterAlertRead(d, m)
+}
+
+func resourceClusterAlertRead(d *schema.ResourceData, m interface{}) error {
+	rancher := m.(Config).Rancher()
+
+	alert, err := rancher.ClusterAlert.ByID(d.Id())
+
+	if err != nil {
+		return err
+	} else if alert == nil {
+		// If the alert DOES NOT EXIST, it has probably already been deleted. Time to update the state...
+		d.SetId("")
+		return nil
+	}
+	d.Set("cluster_id", alert.ClusterID)
+	d.Set("name", alert.Name)
+	d.Set("description", alert.Description)
+	d.Set("severity", alert.Severity)
+	d.Set("initial_wait_seconds", int(alert.InitialWaitSeconds))
+	d.Set("repeat_interval_seconds", int(alert.RepeatIntervalSeconds))
+	if err := d.Set("recipient", flattenAlertRecipients(alert.Recipients)); err != nil {
+		return err
+	}
+	if err := flattenClusterAlertTargets(d, alert); err != nil {
+		return err
+	}
+	d.Set("state", alert.AlertState)
+	d.Set("uuid", alert.UUID)
+	return nil
+}
+
+func resourceClusterAlertUpdate(d *schema.ResourceData, m interface{}) error {
+	rancher := m.(Config).Rancher()
+
+	id := d.Id()
+
+	alert, err := rancher.ClusterAlert.ByID(id)
+	if err != nil {
+		return err
+	}
+	if alert == nil {
+		return fmt.Errorf("cluster alert with ID \"%s\" could not be found", id)
+	}
+
+	updated, err := expandClusterAlert(rancher, d)
+	if err != nil {
+		return err
+	}
+	// Targets which are no longer given have to be removed explicitly, so we always send all of them.
+	if _, err := rancher.ClusterAlert.Update(alert, map[string]interface{}{
+		"name":                  updated.Name,
+		"description":           updated.Description,
+		"severity":              updated.Severity,
+		"initialWaitSeconds":    updated.InitialWaitSeconds,
+		"repeatIntervalSeconds": updated.RepeatIntervalSeconds,
+		"recipients":            updated.Recipients,
+		"targetSystemService":   updated.TargetSystemService,
+		"targetNode":            updated.TargetNode,
+		"targetEvent":           updated.TargetEvent,
+	}); err != nil {
+		return err
+	}
+
+	return resourceClusterAlertRead(d, m)
+}
+
+func resourceClusterAlertDelete(d *schema.ResourceData, m interface{}) error {
+	rancher := m.(Config).Rancher()
+
+	alert, err := rancher.ClusterAlert.ByID(d.Id())
+	if err != nil {
+		return err
+	}
+	if alert == nil {
+		// If the alert DOES NOT EXIST, it has probably already been deleted. Nothing to do for us here...
+		return nil
+	}
+	return rancher.ClusterAlert.Delete(alert)
+}
+
+func resourceClusterAlertExists(d *schema.ResourceData, m interface{}) (bool, error) {
+	rancher := m.(Config).Rancher()
+
+	alert, err := rancher.ClusterAlert.ByID(d.Id())
+	if err != nil {
+		if _, isAPIError := err.(*clientbase.APIError); isAPIError && err.(*clientbase.APIError).StatusCode == 404 {
+			// Ignore 404 errors...
+			return false, nil
+		}
+	}
+	return alert != nil, nil
+}
+
+func resourceClusterAlertState(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if err := resourceClusterAlertRead(d, m); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// nodeAlertSchema returns the schema of the conditions of nodes targeted by a cluster alert, along with the
+// given attribute identifying the nodes.
+func nodeAlertSchema(k string, s *schema.Schema) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		k: s,
+		"condition": {
+			Description:  "Condition of the nodes that raises the alert (\"notready\", \"cpu\" or \"mem\")",
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "notready",
+			ValidateFunc: validation.StringInSlice([]string{"notready", "cpu", "mem"}, false),
+		},
+		"cpu_threshold": {
+			Description: "CPU usage in percent that raises the alert if the condition is \"cpu\"",
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Default:     70,
+		},
+		"mem_threshold": {
+			Description: "Memory usage in percent that raises the alert if the condition is \"mem\"",
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Default:     70,
+		},
+	}
+}
+
+func resourceClusterAlert() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceClusterAlertCreate,
+		Read:   resourceClusterAlertRead,
+		Update: resourceClusterAlertUpdate,
+		Delete: resourceClusterAlertDelete,
+		Exists: resourceClusterAlertExists,
+		Importer: &schema.ResourceImporter{
+			State: resourceClusterAlertState,
+		},
+		Schema: mergeSchemas(alertSchema(), map[string]*schema.Schema{
+			"cluster_id": {
+				Description: "ID of the cluster the alert belongs to",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"system_service": alertTargetSchema("Raises the alert if a system service is unhealthy", clusterAlertTargets, "system_service", map[string]*schema.Schema{
+				"condition": {
+					Description:  "System service to watch (\"etcd\", \"controller-manager\" or \"scheduler\")",
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringInSlice([]string{"etcd", "controller-manager", "scheduler"}, false),
+				},
+			}),
+			"node": alertTargetSchema("Raises the alert if a single node meets the condition", clusterAlertTargets, "node", nodeAlertSchema("node_id", &schema.Schema{
+				Description: "ID of the node to watch",
+				Type:        schema.TypeString,
+				Required:    true,
+			})),
+			"node_selector": alertTargetSchema("Raises the alert if any node matching the selector meets the condition", clusterAlertTargets, "node_selector", nodeAlertSchema("selector", &schema.Schema{
+				Description: "Labels of the nodes to watch",
+				Type:        schema.TypeMap,
+				Required:    true,
+			})),
+			"event": alertTargetSchema("Raises the alert if Kubernetes reports an event", clusterAlertTargets, "event", map[string]*schema.Schema{
+				"event_type": {
+					Description:  "Type of the event (\"Normal\" or \"Warning\")",
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      "Warning",
+					ValidateFunc: validation.StringInSlice([]string{"Normal", "Warning"}, false),
+				},
+				"resource_kind": {
+					Description:  "Kind of the resource the event refers to (e.g. \"Pod\" or \"Node\")",
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringInSlice([]string{"Pod", "Node", "Deployment", "StatefulSet", "DaemonSet"}, false),
+				},
+			}),
+		}),
+	}
+}
@@ -0,0 +1,187 @@
+package rancher2
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/rancher/norman/clientbase"
+	"github.com/rancher/norman/types"
+	"github.com/rancher/types/client/management/v3"
+)
+
+// clusterLogging is the API type of the logging of clusters, which in contrast to the logging of projects is
+// able to include the logs of system components.
+type clusterLogging struct {
+	types.Resource
+	loggingTargets
+	Name                   string            `json:"name,omitempty" yaml:"name,omitempty"`
+	ClusterID              string            `json:"clusterId,omitempty" yaml:"clusterId,omitempty"`
+	OutputFlushInterval    int64             `json:"outputFlushInterval,omitempty" yaml:"outputFlushInterval,omitempty"`
+	OutputTags             map[string]string `json:"outputTags,omitempty" yaml:"outputTags,omitempty"`
+	IncludeSystemComponent *bool             `json:"includeSystemComponent,omitempty" yaml:"includeSystemComponent,omitempty"`
+	State                  string            `json:"state,omitempty" yaml:"state,omitempty"`
+	UUID                   string            `json:"uuid,omitempty" yaml:"uuid,omitempty"`
+}
+
+// clusterLoggingByID returns the logging of a cluster with the given ID if it exists.
+func clusterLoggingByID(c *client.Client, id string) (*clusterLogging, error) {
+	l := &clusterLogging{}
+	if err := c.ByID(client.ClusterLoggingType, id, l); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// expandClusterLogging converts the configured logging of a cluster to its API representation.
+func expandClusterLogging(d *schema.ResourceData) (*clusterLogging, error) {
+	targets, err := expandLoggingTargets(d)
+	if err != nil {
+		return nil, err
+	}
+	includeSystemComponent := d.Get("include_system_component").(bool)
+	return &clusterLogging{
+		loggingTargets:         *targets,
+		Name:                   d.Get("name").(string),
+		ClusterID:              d.Get("cluster_id").(string),
+		OutputFlushInterval:    int64(d.Get("output_flush_interval").(int)),
+		OutputTags:             expandStringMap(d.Get("output_tags")),
+		IncludeSystemComponent: &includeSystemComponent,
+	}, nil
+}
+
+func resourceClusterLoggingCreate(d *schema.ResourceData, m interface{}) error {
+	rancher := m.(Config).Rancher()
+
+	l, err := expandClusterLogging(d)
+	if err != nil {
+		return err
+	}
+	newLogging := &clusterLogging{}
+	if err := rancher.Create(client.ClusterLoggingType, l, newLogging); err != nil {
+		return err
+	}
+	d.SetId(newLogging.ID)
+
+	return resourceClusterLoggingRead(d, m)
+}
+
+func resourceClusterLoggingRead(d *schema.ResourceData, m interface{}) error {
+	rancher := m.(Config).Rancher()
+
+	l, err := clusterLoggingByID(rancher, d.Id())
+
+	if err != nil {
+		return err
+	} else if l == nil {
+		// If the logging DOES NOT EXIST, it has probably already been deleted. Time to update the state...
+		d.SetId("")
+		return nil
+	}
+	d.Set("cluster_id", l.ClusterID)
+	d.Set("name", l.Name)
+	d.Set("output_flush_interval", int(l.OutputFlushInterval))
+	d.Set("output_tags", l.OutputTags)
+	d.Set("include_system_component", l.IncludeSystemComponent == nil || *l.IncludeSystemComponent)
+	if err := flattenLoggingTargets(d, &l.loggingTargets); err != nil {
+		return err
+	}
+	d.Set("state", l.State)
+	d.Set("uuid", l.UUID)
+	return nil
+}
+
+func resourceClusterLoggingUpdate(d *schema.ResourceData, m interface{}) error {
+	rancher := m.(Config).Rancher()
+
+	id := d.Id()
+
+	l, err := clusterLoggingByID(rancher, id)
+	if err != nil {
+		return err
+	}
+	if l == nil {
+		return fmt.Errorf("cluster logging with ID \"%s\" could not be found", id)
+	}
+
+	updated, err := expandClusterLogging(d)
+	if err != nil {
+		return err
+	}
+	updates := updated.loggingTargets.updates()
+	updates["name"] = updated.Name
+	updates["outputFlushInterval"] = updated.OutputFlushInterval
+	updates["outputTags"] = updated.OutputTags
+	updates["includeSystemComponent"] = updated.IncludeSystemComponent
+	if err := rancher.Update(client.ClusterLoggingType, &l.Resource, updates, nil); err != nil {
+		return err
+	}
+
+	return resourceClusterLoggingRead(d, m)
+}
+
+func resourceClusterLoggingDelete(d *schema.ResourceData, m interface{}) error {
+	rancher := m.(Config).Rancher()
+
+	l, err := clusterLoggingByID(rancher, d.Id())
+	if err != nil {
+		return err
+	}
+	if l == nil {
+		// If the logging DOES NOT EXIST, it has probably already been deleted. Nothing to do for us here...
+		return nil
+	}
+	return rancher.Ops.DoResourceDelete(client.ClusterLoggingType, &l.Resource)
+}
+
+func resourceClusterLoggingExists(d *schema.ResourceData, m interface{}) (bool, error) {
+	rancher := m.(Config).Rancher()
+
+	l, err := clusterLoggingByID(rancher, d.Id())
+	if err != nil {
+		if _, isAPIError := err.(*clientbase.APIError); isAPIError && err.(*clientbase.APIError).StatusCode == 404 {
+			// Ignore 404 errors...
+			return false, nil
+		}
+	}
+	return l != nil, nil
+}
+
+func resourceClusterLoggingState(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if err := resourceClusterLoggingRead(d, m); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceClusterLogging() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceClusterLoggingCreate,
+		Read:   resourceClusterLoggingRead,
+		Update: resourceClusterLoggingUpdate,
+		Delete: resourceClusterLoggingDelete,
+		Exists: resourceClusterLoggingExists,
+		Importer: &schema.ResourceImporter{
+			State: resourceClusterLoggingState,
+		},
+		Schema: mergeSchemas(loggingSchema(), map[string]*schema.Schema{
+			"cluster_id": {
+				Description: "ID of the cluster whose logs are forwarded",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"name": {
+				Description: "Name of the logging configuration",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"include_system_component": {
+				Description: "Whether to forward the logs of system components (e.g. of the Kubernetes control plane) as well",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+		}),
+	}
+}
@@ -0,0 +1,182 @@
+package rancher2
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/rancher/norman/clientbase"
+	"github.com/rancher/norman/types"
+	"github.com/rancher/types/client/management/v3"
+)
+
+// globalRoleByName returns the global role with the given name if it exists.
+func globalRoleByName(c *client.Client, name string) (*client.GlobalRole, error) {
+	globalRoles, err := c.GlobalRole.List(&types.ListOpts{
+		Filters: map[string]interface{}{
+			"name": name,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(globalRoles.Data) > 0 {
+		return &globalRoles.Data[0], nil
+	}
+	return nil, nil
+}
+
+func resourceGlobalRoleCreate(d *schema.ResourceData, m interface{}) error {
+	rancher := m.(Config).Rancher()
+
+	name := d.Get("name").(string)
+
+	if globalRole, err := globalRoleByName(rancher, name); err != nil {
+		return err
+	} else if globalRole != nil {
+		return fmt.Errorf("global role with name \"%s\" (ID: \"%s\") does already exist", name, globalRole.ID)
+	}
+
+	globalRole, err := rancher.GlobalRole.Create(&client.GlobalRole{
+		Name:           name,
+		Description:    d.Get("description").(string),
+		NewUserDefault: d.Get("new_user_default").(bool),
+		Rules:          expandPolicyRules(d.Get("rules")),
+	})
+	if err != nil {
+		return err
+	}
+
+	d.SetId(globalRole.ID)
+	d.Set("uuid", globalRole.UUID)
+
+	return nil
+}
+
+func resourceGlobalRoleRead(d *schema.ResourceData, m interface{}) error {
+	rancher := m.(Config).Rancher()
+
+	globalRole, err := rancher.GlobalRole.ByID(d.Id())
+
+	if err != nil {
+		return err
+	} else if globalRole == nil {
+		// If the global role DOES NOT EXIST, it has probably already been deleted. Time to update the state...
+		d.SetId("")
+		return nil
+	}
+	d.Set("name", globalRole.Name)
+	d.Set("description", globalRole.Description)
+	d.Set("new_user_default", globalRole.NewUserDefault)
+	d.Set("uuid", globalRole.UUID)
+	return d.Set("rules", flattenPolicyRules(globalRole.Rules))
+}
+
+func resourceGlobalRoleUpdate(d *schema.ResourceData, m interface{}) error {
+	rancher := m.(Config).Rancher()
+
+	id := d.Id()
+
+	d.Partial(true)
+
+	globalRole, err := rancher.GlobalRole.ByID(id)
+	if err != nil {
+		return err
+	}
+	if globalRole == nil {
+		return fmt.Errorf("global role with ID \"%s\" could not be found", id)
+	}
+
+	updates := map[string]interface{}{}
+	if d.HasChange("name") {
+		updates["name"] = d.Get("name").(string)
+	}
+	if d.HasChange("description") {
+		updates["description"] = d.Get("description").(string)
+	}
+	if d.HasChange("new_user_default") {
+		updates["newUserDefault"] = d.Get("new_user_default").(bool)
+	}
+	if d.HasChange("rules") {
+		updates["rules"] = expandPolicyRules(d.Get("rules"))
+	}
+
+	if _, err = rancher.GlobalRole.Update(globalRole, updates); err != nil {
+		return err
+	}
+
+	d.Partial(false)
+
+	return nil
+
+}
+
+func resourceGlobalRoleDelete(d *schema.ResourceData, m interface{}) error {
+	rancher := m.(Config).Rancher()
+
+	id := d.Id()
+	globalRole, err := rancher.GlobalRole.ByID(id)
+	if err != nil {
+		return err
+	}
+	if globalRole == nil {
+		// If the global role DOES NOT EXIST, it has probably already been deleted. Nothing to do for us here...
+		return nil
+	}
+	return rancher.GlobalRole.Delete(globalRole)
+}
+
+func resourceGlobalRoleExists(d *schema.ResourceData, m interface{}) (bool, error) {
+	rancher := m.(Config).Rancher()
+
+	globalRole, err := rancher.GlobalRole.ByID(d.Id())
+	if err != nil {
+		if _, isAPIError := err.(*clientbase.APIError); isAPIError && err.(*clientbase.APIError).StatusCode == 404 {
+			// Ignore 404 errors...
+			return false, nil
+		}
+	}
+	return globalRole != nil, nil
+}
+
+func resourceGlobalRoleState(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if err := resourceGlobalRoleRead(d, m); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceGlobalRole() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGlobalRoleCreate,
+		Read:   resourceGlobalRoleRead,
+		Update: resourceGlobalRoleUpdate,
+		Delete: resourceGlobalRoleDelete,
+		Exists: resourceGlobalRoleExists,
+		Importer: &schema.ResourceImporter{
+			State: resourceGlobalRoleState,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "Name of the global role",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"description": {
+				Description: "Description of the global role",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"rules": policyRuleSchema(),
+			"new_user_default": {
+				Description: "Whether the global role is granted to new users by default",
+				Type:        schema.TypeBool,
+				Optional:    true,
+			},
+			"uuid": {
+				Description: "UUID of the global role as reported by the Rancher API",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
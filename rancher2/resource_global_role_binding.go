@@ -0,0 +1,175 @@
+package rancher2
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/rancher/norman/clientbase"
+	"github.com/rancher/norman/types"
+	"github.com/rancher/types/client/management/v3"
+)
+
+// globalRoleBinding extends the generated API type by the group principal, which global roles can be bound
+// to as well, but which is not known to the version of the Rancher client library we are using.
+type globalRoleBinding struct {
+	client.GlobalRoleBinding
+	GroupPrincipalID string `json:"groupPrincipalId,omitempty" yaml:"groupPrincipalId,omitempty"`
+}
+
+// globalRoleBindingsByUser returns all global role bindings of the given user.
+func globalRoleBindingsByUser(c *client.Client, userID string) ([]client.GlobalRoleBinding, error) {
+	collection, err := c.GlobalRoleBinding.List(&types.ListOpts{
+		Filters: map[string]interface{}{
+			"userId": userID,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	bindings := make([]client.GlobalRoleBinding, 0, len(collection.Data))
+	for collection != nil {
+		for _, binding := range collection.Data {
+			if binding.UserID == userID {
+				bindings = append(bindings, binding)
+			}
+		}
+		if collection, err = collection.Next(); err != nil {
+			return nil, err
+		}
+	}
+	return bindings, nil
+}
+
+// globalRoleBindingByID returns the global role binding with the given ID if it exists.
+func globalRoleBindingByID(c *client.Client, id string) (*globalRoleBinding, error) {
+	binding := &globalRoleBinding{}
+	if err := c.ByID(client.GlobalRoleBindingType, id, binding); err != nil {
+		return nil, err
+	}
+	return binding, nil
+}
+
+func resourceGlobalRoleBindingCreate(d *schema.ResourceData, m interface{}) error {
+	rancher := m.(Config).Rancher()
+
+	binding := &globalRoleBinding{
+		GlobalRoleBinding: client.GlobalRoleBinding{
+			GlobalRoleID: d.Get("global_role_id").(string),
+			UserID:       d.Get("user_id").(string),
+		},
+		GroupPrincipalID: d.Get("group_principal_id").(string),
+	}
+	if binding.UserID == "" && binding.GroupPrincipalID == "" {
+		return fmt.Errorf("one of user_id or group_principal_id has to be specified")
+	}
+
+	newBinding := &globalRoleBinding{}
+	if err := rancher.Create(client.GlobalRoleBindingType, binding, newBinding); err != nil {
+		return err
+	}
+
+	d.SetId(newBinding.ID)
+	d.Set("name", newBinding.Name)
+	d.Set("uuid", newBinding.UUID)
+
+	return nil
+}
+
+func resourceGlobalRoleBindingRead(d *schema.ResourceData, m interface{}) error {
+	rancher := m.(Config).Rancher()
+
+	binding, err := globalRoleBindingByID(rancher, d.Id())
+
+	if err != nil {
+		return err
+	} else if binding == nil {
+		// If the binding DOES NOT EXIST, it has probably already been deleted. Time to update the state...
+		d.SetId("")
+		return nil
+	}
+	d.Set("global_role_id", binding.GlobalRoleID)
+	d.Set("user_id", binding.UserID)
+	d.Set("group_principal_id", binding.GroupPrincipalID)
+	d.Set("name", binding.Name)
+	d.Set("uuid", binding.UUID)
+	return nil
+}
+
+func resourceGlobalRoleBindingDelete(d *schema.ResourceData, m interface{}) error {
+	rancher := m.(Config).Rancher()
+
+	id := d.Id()
+	binding, err := globalRoleBindingByID(rancher, id)
+	if err != nil {
+		return err
+	}
+	if binding == nil {
+		// If the binding DOES NOT EXIST, it has probably already been deleted. Nothing to do for us here...
+		return nil
+	}
+	return rancher.GlobalRoleBinding.Delete(&binding.GlobalRoleBinding)
+}
+
+func resourceGlobalRoleBindingExists(d *schema.ResourceData, m interface{}) (bool, error) {
+	rancher := m.(Config).Rancher()
+
+	binding, err := globalRoleBindingByID(rancher, d.Id())
+	if err != nil {
+		if _, isAPIError := err.(*clientbase.APIError); isAPIError && err.(*clientbase.APIError).StatusCode == 404 {
+			// Ignore 404 errors...
+			return false, nil
+		}
+	}
+	return binding != nil, nil
+}
+
+func resourceGlobalRoleBindingState(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if err := resourceGlobalRoleBindingRead(d, m); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceGlobalRoleBinding() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGlobalRoleBindingCreate,
+		Read:   resourceGlobalRoleBindingRead,
+		Delete: resourceGlobalRoleBindingDelete,
+		Exists: resourceGlobalRoleBindingExists,
+		Importer: &schema.ResourceImporter{
+			State: resourceGlobalRoleBindingState,
+		},
+		Schema: map[string]*schema.Schema{
+			"global_role_id": {
+				Description: "ID of the global role to bind (e.g. \"admin\" or \"user\")",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"user_id": {
+				Description:   "ID of the Rancher user the global role shall be bound to",
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"group_principal_id"},
+			},
+			"group_principal_id": {
+				Description:   "ID of the group principal the global role shall be bound to",
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"user_id"},
+			},
+			"name": {
+				Description: "Name of the binding as generated by Rancher",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"uuid": {
+				Description: "UUID of the binding as reported by the Rancher API",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
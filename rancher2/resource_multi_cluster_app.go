@@ -0,0 +1,609 @@
+package rancher2
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/rancher/norman/clientbase"
+	"github.com/rancher/norman/types"
+	"github.com/rancher/types/client/management/v3"
+)
+
+const multiClusterAppType = "multiClusterApp"
+
+// multiClusterApp is the API type of apps deployed to several projects across clusters, which is not known
+// to the version of the Rancher client library we are using.
+type multiClusterApp struct {
+	types.Resource
+	Name                 string                          `json:"name,omitempty" yaml:"name,omitempty"`
+	TemplateVersionID    string                          `json:"templateVersionId,omitempty" yaml:"templateVersionId,omitempty"`
+	Targets              []multiClusterAppTarget         `json:"targets,omitempty" yaml:"targets,omitempty"`
+	Answers              []multiClusterAppAnswer         `json:"answers,omitempty" yaml:"answers,omitempty"`
+	Roles                []string                        `json:"roles,omitempty" yaml:"roles,omitempty"`
+	UpgradeStrategy      *multiClusterAppUpgradeStrategy `json:"upgradeStrategy,omitempty" yaml:"upgradeStrategy,omitempty"`
+	Status               *multiClusterAppStatus          `json:"status,omitempty" yaml:"status,omitempty"`
+	State                string                          `json:"state,omitempty" yaml:"state,omitempty"`
+	Transitioning        string                          `json:"transitioning,omitempty" yaml:"transitioning,omitempty"`
+	TransitioningMessage string                          `json:"transitioningMessage,omitempty" yaml:"transitioningMessage,omitempty"`
+	UUID                 string                          `json:"uuid,omitempty" yaml:"uuid,omitempty"`
+}
+
+// multiClusterAppTarget is a project a multi-cluster app is deployed to, along with the status of the app
+// deployed there.
+type multiClusterAppTarget struct {
+	ProjectID   string `json:"projectId,omitempty" yaml:"projectId,omitempty"`
+	AppID       string `json:"appId,omitempty" yaml:"appId,omitempty"`
+	State       string `json:"state,omitempty" yaml:"state,omitempty"`
+	HealthState string `json:"healthState,omitempty" yaml:"healthState,omitempty"`
+}
+
+// multiClusterAppAnswer holds answers to the questions of the catalog template. Answers without project
+// and cluster apply to all targets.
+type multiClusterAppAnswer struct {
+	ProjectID string            `json:"projectId,omitempty" yaml:"projectId,omitempty"`
+	ClusterID string            `json:"clusterId,omitempty" yaml:"clusterId,omitempty"`
+	Values    map[string]string `json:"values,omitempty" yaml:"values,omitempty"`
+}
+
+// multiClusterAppUpgradeStrategy defines how the apps of the targets are upgraded.
+type multiClusterAppUpgradeStrategy struct {
+	RollingUpdate *multiClusterAppRollingUpdate `json:"rollingUpdate,omitempty" yaml:"rollingUpdate,omitempty"`
+}
+
+// multiClusterAppRollingUpdate upgrades the apps of the targets in batches.
+type multiClusterAppRollingUpdate struct {
+	BatchSize int64 `json:"batchSize,omitempty" yaml:"batchSize,omitempty"`
+	Interval  int64 `json:"interval,omitempty" yaml:"interval,omitempty"`
+}
+
+// multiClusterAppStatus holds the revision of the catalog template version and answers currently deployed.
+type multiClusterAppStatus struct {
+	RevisionID string `json:"revisionId,omitempty" yaml:"revisionId,omitempty"`
+}
+
+// multiClusterAppUpdateTargets is the input of the "addProjects" and "removeProjects" actions of
+// multi-cluster apps.
+type multiClusterAppUpdateTargets struct {
+	Projects []string                `json:"projects,omitempty" yaml:"projects,omitempty"`
+	Answers  []multiClusterAppAnswer `json:"answers,omitempty" yaml:"answers,omitempty"`
+}
+
+// multiClusterAppByID returns the multi-cluster app with the given ID if it exists.
+func multiClusterAppByID(c *client.Client, id string) (*multiClusterApp, error) {
+	a := &multiClusterApp{}
+	if err := c.ByID(multiClusterAppType, id, a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// multiClusterAppConverged checks whether the given multi-cluster app has been deployed to all of its
+// targets. It fails if deploying the app failed.
+func multiClusterAppConverged(a *multiClusterApp) (bool, error) {
+	if a.Transitioning == "error" {
+		return false, fmt.Errorf("multi-cluster app \"%s\" could not be deployed: %s", a.Name, a.TransitioningMessage)
+	}
+	if a.State != "active" || a.Transitioning == "yes" {
+		return false, nil
+	}
+	for _, t := range a.Targets {
+		if t.AppID == "" || t.State != "active" {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// multiClusterAppRevisionID returns the ID of the revision the given multi-cluster app is deployed with.
+func multiClusterAppRevisionID(a *multiClusterApp) string {
+	if a.Status == nil {
+		return ""
+	}
+	return a.Status.RevisionID
+}
+
+// waitForMultiClusterApp waits until the multi-cluster app with the given ID has been deployed to all of its
+// targets. When a new revision of the app is expected, the revision it has been deployed with before has to be
+// given. Right after updating the app, it still reports the state of that revision, so we wait for the new
+// revision to show up first.
+func waitForMultiClusterApp(c *client.Client, id string, previousRevisionID string, timeout time.Duration) error {
+	started := previousRevisionID == ""
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"deploying"},
+		Target:  []string{"deployed"},
+		Refresh: func() (interface{}, string, error) {
+			a, err := multiClusterAppByID(c, id)
+			if err != nil {
+				return nil, "", err
+			}
+			started = started || multiClusterAppRevisionID(a) != previousRevisionID || a.Transitioning == "yes"
+			if !started {
+				return a, "deploying", nil
+			}
+			converged, err := multiClusterAppConverged(a)
+			if err != nil {
+				return nil, "", err
+			}
+			if !converged {
+				return a, "deploying", nil
+			}
+			return a, "deployed", nil
+		},
+		Timeout:    timeout,
+		Delay:      2 * time.Second,
+		MinTimeout: 2 * time.Second,
+	}
+	_, err := stateConf.WaitForState()
+	return err
+}
+
+// expandMultiClusterAppTargetProjects returns the IDs of the configured target projects.
+func expandMultiClusterAppTargetProjects(v interface{}) []string {
+	list := v.([]interface{})
+	projects := make([]string, 0, len(list))
+	for _, t := range list {
+		projects = append(projects, t.(map[string]interface{})["project_id"].(string))
+	}
+	return projects
+}
+
+// expandMultiClusterAppTargets converts the configured targets to their API representation.
+func expandMultiClusterAppTargets(v interface{}) []multiClusterAppTarget {
+	projects := expandMultiClusterAppTargetProjects(v)
+	targets := make([]multiClusterAppTarget, 0, len(projects))
+	for _, projectID := range projects {
+		targets = append(targets, multiClusterAppTarget{ProjectID: projectID})
+	}
+	return targets
+}
+
+// expandMultiClusterAppAnswers converts the answers applying to all targets and the answers overridden by
+// single targets to their API representation.
+func expandMultiClusterAppAnswers(answers interface{}, targets interface{}) []multiClusterAppAnswer {
+	result := []multiClusterAppAnswer{}
+	if values := expandStringMap(answers); len(values) > 0 {
+		result = append(result, multiClusterAppAnswer{Values: values})
+	}
+	for _, t := range targets.([]interface{}) {
+		target := t.(map[string]interface{})
+		if values := expandStringMap(target["answers"]); len(values) > 0 {
+			result = append(result, multiClusterAppAnswer{
+				ProjectID: target["project_id"].(string),
+				Values:    values,
+			})
+		}
+	}
+	return result
+}
+
+// multiClusterAppAnswersChanged checks whether the answers sent when updating a multi-cluster app differ from
+// the previous ones, which results in a new revision. The answers of target projects which are added or removed
+// are handled by the respective actions, so only the answers of projects which remain targets are compared.
+func multiClusterAppAnswersChanged(oldAnswers, oldTargets, newAnswers, newTargets interface{}) bool {
+	oldProjects := map[string]bool{}
+	for _, projectID := range expandMultiClusterAppTargetProjects(oldTargets) {
+		oldProjects[projectID] = true
+	}
+	remaining := map[string]bool{"": true}
+	for _, projectID := range expandMultiClusterAppTargetProjects(newTargets) {
+		remaining[projectID] = oldProjects[projectID]
+	}
+	values := func(answers []multiClusterAppAnswer) map[string]map[string]string {
+		v := map[string]map[string]string{}
+		for _, answer := range answers {
+			if remaining[answer.ProjectID] {
+				v[answer.ProjectID] = answer.Values
+			}
+		}
+		return v
+	}
+	return !reflect.DeepEqual(values(expandMultiClusterAppAnswers(oldAnswers, oldTargets)),
+		values(expandMultiClusterAppAnswers(newAnswers, newTargets)))
+}
+
+// expandMultiClusterAppUpgradeStrategy converts the configured upgrade strategy to its API representation.
+func expandMultiClusterAppUpgradeStrategy(v interface{}) *multiClusterAppUpgradeStrategy {
+	list := v.([]interface{})
+	if len(list) == 0 || list[0] == nil {
+		return nil
+	}
+	strategy := list[0].(map[string]interface{})
+	return &multiClusterAppUpgradeStrategy{
+		RollingUpdate: &multiClusterAppRollingUpdate{
+			BatchSize: int64(strategy["batch_size"].(int)),
+			Interval:  int64(strategy["interval"].(int)),
+		},
+	}
+}
+
+// flattenMultiClusterAppTargets converts the targets and their answers as returned by the API to their
+// terraform representation. Targets keep the order they have been configured in, so that a different order
+// returned by the API is not reported as a change. Targets which have not been configured are appended.
+func flattenMultiClusterAppTargets(configured []string, targets []multiClusterAppTarget, answers []multiClusterAppAnswer) []interface{} {
+	values := map[string]map[string]string{}
+	for _, a := range answers {
+		if a.ProjectID != "" {
+			values[a.ProjectID] = a.Values
+		}
+	}
+
+	byProject := map[string]multiClusterAppTarget{}
+	for _, t := range targets {
+		byProject[t.ProjectID] = t
+	}
+	ordered := make([]multiClusterAppTarget, 0, len(targets))
+	for _, projectID := range configured {
+		if t, ok := byProject[projectID]; ok {
+			ordered = append(ordered, t)
+			delete(byProject, projectID)
+		}
+	}
+	for _, t := range targets {
+		if _, ok := byProject[t.ProjectID]; ok {
+			ordered = append(ordered, t)
+		}
+	}
+
+	list := make([]interface{}, 0, len(ordered))
+	for _, t := range ordered {
+		list = append(list, map[string]interface{}{
+			"project_id":   t.ProjectID,
+			"answers":      values[t.ProjectID],
+			"app_id":       t.AppID,
+			"state":        t.State,
+			"health_state": t.HealthState,
+		})
+	}
+	return list
+}
+
+// flattenMultiClusterAppAnswers returns the answers applying to all targets.
+func flattenMultiClusterAppAnswers(answers []multiClusterAppAnswer) map[string]string {
+	for _, a := range answers {
+		if a.ProjectID == "" && a.ClusterID == "" {
+			return a.Values
+		}
+	}
+	return nil
+}
+
+// flattenMultiClusterAppUpgradeStrategy converts the upgrade strategy as returned by the API to its terraform
+// representation.
+func flattenMultiClusterAppUpgradeStrategy(strategy *multiClusterAppUpgradeStrategy) []interface{} {
+	if strategy == nil || strategy.RollingUpdate == nil {
+		return []interface{}{}
+	}
+	return []interface{}{
+		map[string]interface{}{
+			"batch_size": int(strategy.RollingUpdate.BatchSize),
+			"interval":   int(strategy.RollingUpdate.Interval),
+		},
+	}
+}
+
+func resourceMultiClusterAppCreate(d *schema.ResourceData, m interface{}) error {
+	rancher := m.(Config).Rancher()
+
+	newApp := &multiClusterApp{}
+	if err := rancher.Create(multiClusterAppType, &multiClusterApp{
+		Name:              d.Get("name").(string),
+		TemplateVersionID: d.Get("template_version_id").(string),
+		Targets:           expandMultiClusterAppTargets(d.Get("target")),
+		Answers:           expandMultiClusterAppAnswers(d.Get("answers"), d.Get("target")),
+		Roles:             expandStringSet(d.Get("roles")),
+		UpgradeStrategy:   expandMultiClusterAppUpgradeStrategy(d.Get("upgrade_strategy")),
+	}, newApp); err != nil {
+		return err
+	}
+	d.SetId(newApp.ID)
+
+	if err := waitForMultiClusterApp(rancher, newApp.ID, "", d.Timeout(schema.TimeoutCreate)); err != nil {
+		return err
+	}
+	return resourceMultiClusterAppRead(d, m)
+}
+
+func resourceMultiClusterAppRead(d *schema.ResourceData, m interface{}) error {
+	rancher := m.(Config).Rancher()
+
+	a, err := multiClusterAppByID(rancher, d.Id())
+
+	if err != nil {
+		return err
+	} else if a == nil {
+		// If the app DOES NOT EXIST, it has probably already been deleted. Time to update the state...
+		d.SetId("")
+		return nil
+	}
+	d.Set("name", a.Name)
+	d.Set("template_version_id", a.TemplateVersionID)
+	d.Set("answers", flattenMultiClusterAppAnswers(a.Answers))
+	if err := d.Set("target", flattenMultiClusterAppTargets(expandMultiClusterAppTargetProjects(d.Get("target")), a.Targets, a.Answers)); err != nil {
+		return err
+	}
+	d.Set("roles", flattenStringSet(a.Roles))
+	if err := d.Set("upgrade_strategy", flattenMultiClusterAppUpgradeStrategy(a.UpgradeStrategy)); err != nil {
+		return err
+	}
+	d.Set("revision_id", multiClusterAppRevisionID(a))
+	d.Set("state", a.State)
+	d.Set("uuid", a.UUID)
+	return nil
+}
+
+func resourceMultiClusterAppUpdate(d *schema.ResourceData, m interface{}) error {
+	rancher := m.(Config).Rancher()
+
+	id := d.Id()
+
+	d.Partial(true)
+
+	a, err := multiClusterAppByID(rancher, id)
+	if err != nil {
+		return err
+	}
+	if a == nil {
+		return fmt.Errorf("multi-cluster app with ID \"%s\" could not be found", id)
+	}
+
+	// Targets cannot be changed by updating the app, but only by adding and removing projects.
+	if d.HasChange("target") {
+		o, n := d.GetChange("target")
+		oldProjects := map[string]bool{}
+		for _, projectID := range expandMultiClusterAppTargetProjects(o) {
+			oldProjects[projectID] = true
+		}
+		newProjects := map[string]bool{}
+		added := []string{}
+		for _, projectID := range expandMultiClusterAppTargetProjects(n) {
+			newProjects[projectID] = true
+			if !oldProjects[projectID] {
+				added = append(added, projectID)
+			}
+		}
+		removed := []string{}
+		for projectID := range oldProjects {
+			if !newProjects[projectID] {
+				removed = append(removed, projectID)
+			}
+		}
+
+		if len(removed) > 0 {
+			if err := rancher.Action(multiClusterAppType, "removeProjects", &a.Resource, &multiClusterAppUpdateTargets{
+				Projects: removed,
+			}, nil); err != nil {
+				return fmt.Errorf("unable to remove projects from multi-cluster app \"%s\": %v", a.Name, err)
+			}
+		}
+		if len(added) > 0 {
+			answers := []multiClusterAppAnswer{}
+			for _, answer := range expandMultiClusterAppAnswers(d.Get("answers"), n) {
+				for _, projectID := range added {
+					if answer.ProjectID == projectID {
+						answers = append(answers, answer)
+					}
+				}
+			}
+			if err := rancher.Action(multiClusterAppType, "addProjects", &a.Resource, &multiClusterAppUpdateTargets{
+				Projects: added,
+				Answers:  answers,
+			}, nil); err != nil {
+				return fmt.Errorf("unable to add projects to multi-cluster app \"%s\": %v", a.Name, err)
+			}
+		}
+		if a, err = multiClusterAppByID(rancher, id); err != nil {
+			return err
+		}
+	}
+
+	update := false
+	for _, attribute := range []string{"template_version_id", "answers", "target", "roles", "upgrade_strategy"} {
+		update = update || d.HasChange(attribute)
+	}
+	if update {
+		// The upgrade strategy is sent even if it has been removed, as omitting it would keep the current one.
+		upgradeStrategy := expandMultiClusterAppUpgradeStrategy(d.Get("upgrade_strategy"))
+		if upgradeStrategy == nil {
+			upgradeStrategy = &multiClusterAppUpgradeStrategy{}
+		}
+		// Only changes of the template version or answers result in a new revision.
+		oldAnswers, newAnswers := d.GetChange("answers")
+		oldTargets, newTargets := d.GetChange("target")
+		previousRevisionID := ""
+		if d.HasChange("template_version_id") || multiClusterAppAnswersChanged(oldAnswers, oldTargets, newAnswers, newTargets) {
+			previousRevisionID = multiClusterAppRevisionID(a)
+		}
+		if err := rancher.Update(multiClusterAppType, &a.Resource, map[string]interface{}{
+			"templateVersionId": d.Get("template_version_id").(string),
+			"answers":           expandMultiClusterAppAnswers(d.Get("answers"), d.Get("target")),
+			"roles":             expandStringSet(d.Get("roles")),
+			"upgradeStrategy":   upgradeStrategy,
+		}, nil); err != nil {
+			return fmt.Errorf("unable to update multi-cluster app \"%s\": %v", a.Name, err)
+		}
+		if err := waitForMultiClusterApp(rancher, id, previousRevisionID, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return err
+		}
+	}
+
+	d.Partial(false)
+
+	return resourceMultiClusterAppRead(d, m)
+}
+
+func resourceMultiClusterAppDelete(d *schema.ResourceData, m interface{}) error {
+	rancher := m.(Config).Rancher()
+
+	id := d.Id()
+	a, err := multiClusterAppByID(rancher, id)
+	if err != nil {
+		return err
+	}
+	if a == nil {
+		// If the app DOES NOT EXIST, it has probably already been deleted. Nothing to do for us here...
+		return nil
+	}
+	if err := rancher.Ops.DoResourceDelete(multiClusterAppType, &a.Resource); err != nil {
+		return err
+	}
+
+	// Removing a multi-cluster app takes a while, as the apps of all targets have to be removed first.
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"removing"},
+		Target:  []string{"removed"},
+		Refresh: func() (interface{}, string, error) {
+			a, err := multiClusterAppByID(rancher, id)
+			if err != nil {
+				if apiError, isAPIError := err.(*clientbase.APIError); isAPIError && apiError.StatusCode == 404 {
+					return id, "removed", nil
+				}
+				return nil, "", err
+			}
+			return a, "removing", nil
+		},
+		Timeout:    d.Timeout(schema.TimeoutDelete),
+		Delay:      2 * time.Second,
+		MinTimeout: 2 * time.Second,
+	}
+	_, err = stateConf.WaitForState()
+	return err
+}
+
+func resourceMultiClusterAppExists(d *schema.ResourceData, m interface{}) (bool, error) {
+	rancher := m.(Config).Rancher()
+
+	a, err := multiClusterAppByID(rancher, d.Id())
+	if err != nil {
+		if _, isAPIError := err.(*clientbase.APIError); isAPIError && err.(*clientbase.APIError).StatusCode == 404 {
+			// Ignore 404 errors...
+			return false, nil
+		}
+	}
+	return a != nil, nil
+}
+
+func resourceMultiClusterAppState(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if err := resourceMultiClusterAppRead(d, m); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceMultiClusterApp() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceMultiClusterAppCreate,
+		Read:   resourceMultiClusterAppRead,
+		Update: resourceMultiClusterAppUpdate,
+		Delete: resourceMultiClusterAppDelete,
+		Exists: resourceMultiClusterAppExists,
+		Importer: &schema.ResourceImporter{
+			State: resourceMultiClusterAppState,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "Name of the multi-cluster app",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"template_version_id": {
+				Description: "ID of the catalog template version the apps are deployed from (see the rancher2_catalog_template data source)",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"target": {
+				Description: "Projects the app is deployed to",
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"project_id": {
+							Description: "ID of the project",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"answers": {
+							Description: "Answers overriding the answers of all targets for this project",
+							Type:        schema.TypeMap,
+							Optional:    true,
+						},
+						"app_id": {
+							Description: "ID of the app deployed to the project",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"state": {
+							Description: "State of the app deployed to the project",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"health_state": {
+							Description: "Health of the app deployed to the project",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"answers": {
+				Description: "Answers to the questions of the catalog template for all targets",
+				Type:        schema.TypeMap,
+				Optional:    true,
+			},
+			"roles": {
+				Description: "Roles the apps are deployed with in the target projects (e.g. \"project-member\")",
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+			},
+			"upgrade_strategy": {
+				Description: "Strategy the apps of the targets are upgraded with",
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"batch_size": {
+							Description: "Number of targets upgraded at the same time",
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Default:     1,
+						},
+						"interval": {
+							Description: "Seconds to wait between upgrading two batches of targets",
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Default:     0,
+						},
+					},
+				},
+			},
+			"revision_id": {
+				Description: "ID of the current revision of the multi-cluster app",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"state": {
+				Description: "State of the multi-cluster app as reported by the Rancher API",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"uuid": {
+				Description: "UUID of the multi-cluster app as reported by the Rancher API",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
@@ -0,0 +1,125 @@
+package rancher2
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMultiClusterAppConverged(t *testing.T) {
+	tt := []struct {
+		app         multiClusterApp
+		expected    bool
+		errExpected bool
+	}{
+		{app: multiClusterApp{State: "active", Targets: []multiClusterAppTarget{
+			{ProjectID: "c-abcde:p-fghij", AppID: "p-fghij:app-1", State: "active"},
+			{ProjectID: "c-klmno:p-pqrst", AppID: "p-pqrst:app-2", State: "active"},
+		}}, expected: true},
+		{app: multiClusterApp{State: "active", Targets: []multiClusterAppTarget{
+			{ProjectID: "c-abcde:p-fghij", AppID: "p-fghij:app-1", State: "active"},
+			{ProjectID: "c-klmno:p-pqrst"},
+		}}, expected: false},
+		{app: multiClusterApp{State: "active", Targets: []multiClusterAppTarget{
+			{ProjectID: "c-abcde:p-fghij", AppID: "p-fghij:app-1", State: "installing"},
+		}}, expected: false},
+		{app: multiClusterApp{State: "deploying", Transitioning: "yes"}, expected: false},
+		{app: multiClusterApp{State: "deploying", Transitioning: "error", TransitioningMessage: "template version not found"}, errExpected: true},
+	}
+	for i, td := range tt {
+		converged, err := multiClusterAppConverged(&td.app)
+		if (td.errExpected && err == nil) || (!td.errExpected && err != nil) {
+			t.Errorf("unexpected error result for multi-cluster app %d: %v", i, err)
+			continue
+		}
+		if converged != td.expected {
+			t.Errorf("unexpected result for multi-cluster app %d: %v", i, converged)
+		}
+	}
+}
+
+func TestMultiClusterAppAnswers(t *testing.T) {
+	targets := []interface{}{
+		map[string]interface{}{"project_id": "c-abcde:p-fghij", "answers": map[string]interface{}{"replicas": "3"}},
+		map[string]interface{}{"project_id": "c-klmno:p-pqrst", "answers": map[string]interface{}{}},
+	}
+	answers := expandMultiClusterAppAnswers(map[string]interface{}{"replicas": "1", "image": "fluentd"}, targets)
+	expectedAnswers := []multiClusterAppAnswer{
+		{Values: map[string]string{"replicas": "1", "image": "fluentd"}},
+		{ProjectID: "c-abcde:p-fghij", Values: map[string]string{"replicas": "3"}},
+	}
+	if !reflect.DeepEqual(answers, expectedAnswers) {
+		t.Fatalf("unexpected answers: %+v", answers)
+	}
+	if values := flattenMultiClusterAppAnswers(answers); !reflect.DeepEqual(values, expectedAnswers[0].Values) {
+		t.Errorf("unexpected answers of all targets: %+v", values)
+	}
+
+	// Targets keep their configured order, unknown targets are appended.
+	flattened := flattenMultiClusterAppTargets([]string{"c-abcde:p-fghij", "c-klmno:p-pqrst"}, []multiClusterAppTarget{
+		{ProjectID: "c-uvwxy:p-zabcd", AppID: "p-zabcd:app-3", State: "active"},
+		{ProjectID: "c-klmno:p-pqrst", AppID: "p-pqrst:app-2", State: "active"},
+		{ProjectID: "c-abcde:p-fghij", AppID: "p-fghij:app-1", State: "active", HealthState: "healthy"},
+	}, answers)
+	expectedTargets := []interface{}{
+		map[string]interface{}{"project_id": "c-abcde:p-fghij", "answers": map[string]string{"replicas": "3"}, "app_id": "p-fghij:app-1", "state": "active", "health_state": "healthy"},
+		map[string]interface{}{"project_id": "c-klmno:p-pqrst", "answers": map[string]string(nil), "app_id": "p-pqrst:app-2", "state": "active", "health_state": ""},
+		map[string]interface{}{"project_id": "c-uvwxy:p-zabcd", "answers": map[string]string(nil), "app_id": "p-zabcd:app-3", "state": "active", "health_state": ""},
+	}
+	if !reflect.DeepEqual(flattened, expectedTargets) {
+		t.Errorf("unexpected targets: %+v", flattened)
+	}
+}
+
+func TestMultiClusterAppAnswersChanged(t *testing.T) {
+	target := func(projectID string, answers map[string]interface{}) interface{} {
+		return map[string]interface{}{"project_id": projectID, "answers": answers}
+	}
+	answers := map[string]interface{}{"replicas": "1"}
+	targets := []interface{}{
+		target("c-abcde:p-fghij", map[string]interface{}{"replicas": "3"}),
+		target("c-klmno:p-pqrst", map[string]interface{}{}),
+	}
+	tt := []struct {
+		answers  map[string]interface{}
+		targets  []interface{}
+		expected bool
+	}{
+		{answers: answers, targets: targets, expected: false},
+		// Answers of all targets changed
+		{answers: map[string]interface{}{"replicas": "2"}, targets: targets, expected: true},
+		// Answers of a single target changed
+		{
+			answers: answers,
+			targets: []interface{}{
+				target("c-abcde:p-fghij", map[string]interface{}{"replicas": "5"}),
+				target("c-klmno:p-pqrst", map[string]interface{}{}),
+			},
+			expected: true,
+		},
+		// Answers added to a remaining target
+		{
+			answers: answers,
+			targets: []interface{}{
+				target("c-abcde:p-fghij", map[string]interface{}{"replicas": "3"}),
+				target("c-klmno:p-pqrst", map[string]interface{}{"replicas": "2"}),
+			},
+			expected: true,
+		},
+		// Targets reordered
+		{answers: answers, targets: []interface{}{targets[1], targets[0]}, expected: false},
+		// Answers of added and removed targets are handled by adding and removing projects
+		{
+			answers: answers,
+			targets: []interface{}{
+				target("c-abcde:p-fghij", map[string]interface{}{"replicas": "3"}),
+				target("c-uvwxy:p-zabcd", map[string]interface{}{"replicas": "4"}),
+			},
+			expected: false,
+		},
+	}
+	for i, td := range tt {
+		if changed := multiClusterAppAnswersChanged(answers, targets, td.answers, td.targets); changed != td.expected {
+			t.Errorf("unexpected result for answers %d: %v", i, changed)
+		}
+	}
+}
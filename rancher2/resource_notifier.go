@@ -0,0 +1,474 @@
+package rancher2
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/rancher/norman/clientbase"
+	"github.com/rancher/norman/types"
+	"github.com/rancher/types/client/management/v3"
+)
+
+// notifierConfigs lists the attributes configuring how a notifier sends notifications. Exactly one of them
+// has to be given.
+var notifierConfigs = []string{"slack_config", "smtp_config", "pagerduty_config", "webhook_config", "wechat_config"}
+
+// wechatConfig configures notifiers sending notifications via WeChat, which is not known to the version of
+// the Rancher client library we are using.
+type wechatConfig struct {
+	CorpID           string `json:"corpId,omitempty" yaml:"corpId,omitempty"`
+	Agent            string `json:"agent,omitempty" yaml:"agent,omitempty"`
+	Secret           string `json:"secret,omitempty" yaml:"secret,omitempty"`
+	RecipientType    string `json:"recipientType,omitempty" yaml:"recipientType,omitempty"`
+	DefaultRecipient string `json:"defaultRecipient,omitempty" yaml:"defaultRecipient,omitempty"`
+}
+
+// notifier extends the generated API type by the WeChat configuration.
+type notifier struct {
+	client.Notifier
+	WechatConfig *wechatConfig `json:"wechatConfig,omitempty" yaml:"wechatConfig,omitempty"`
+}
+
+// notification extends the generated input of the "send" action by the WeChat configuration.
+type notification struct {
+	client.Notification
+	WechatConfig *wechatConfig `json:"wechatConfig,omitempty" yaml:"wechatConfig,omitempty"`
+}
+
+// notifierByID returns the notifier with the given ID if it exists.
+func notifierByID(c *client.Client, id string) (*notifier, error) {
+	n := &notifier{}
+	if err := c.ByID(client.NotifierType, id, n); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+// expandNotifier converts the configured notifier to its API representation.
+func expandNotifier(d *schema.ResourceData) (*notifier, error) {
+	n := &notifier{
+		Notifier: client.Notifier{
+			ClusterID:   d.Get("cluster_id").(string),
+			Name:        d.Get("name").(string),
+			Description: d.Get("description").(string),
+		},
+	}
+	configs := 0
+	if config := configBlock(d.Get("slack_config")); config != nil {
+		configs++
+		n.SlackConfig = &client.SlackConfig{
+			URL:              config["url"].(string),
+			DefaultRecipient: config["default_recipient"].(string),
+		}
+	}
+	if config := configBlock(d.Get("smtp_config")); config != nil {
+		configs++
+		n.SMTPConfig = &client.SMTPConfig{
+			Host:             config["host"].(string),
+			Port:             int64(config["port"].(int)),
+			TLS:              config["tls"].(bool),
+			Username:         config["username"].(string),
+			Password:         config["password"].(string),
+			Sender:           config["sender"].(string),
+			DefaultRecipient: config["default_recipient"].(string),
+		}
+	}
+	if config := configBlock(d.Get("pagerduty_config")); config != nil {
+		configs++
+		n.PagerdutyConfig = &client.PagerdutyConfig{
+			ServiceKey: config["service_key"].(string),
+		}
+	}
+	if config := configBlock(d.Get("webhook_config")); config != nil {
+		configs++
+		n.WebhookConfig = &client.WebhookConfig{
+			URL: config["url"].(string),
+		}
+	}
+	if config := configBlock(d.Get("wechat_config")); config != nil {
+		configs++
+		n.WechatConfig = &wechatConfig{
+			CorpID:           config["corp_id"].(string),
+			Agent:            config["agent"].(string),
+			Secret:           config["secret"].(string),
+			RecipientType:    config["recipient_type"].(string),
+			DefaultRecipient: config["default_recipient"].(string),
+		}
+	}
+	if configs != 1 {
+		return nil, fmt.Errorf("exactly one of %s has to be given", strings.Join(notifierConfigs, ", "))
+	}
+	return n, nil
+}
+
+// flattenNotifierConfigs updates the terraform state with the configuration of the given notifier.
+func flattenNotifierConfigs(d *schema.ResourceData, n *notifier) error {
+	configs := map[string][]interface{}{}
+	for _, k := range notifierConfigs {
+		configs[k] = []interface{}{}
+	}
+	if config := n.SlackConfig; config != nil {
+		configs["slack_config"] = []interface{}{map[string]interface{}{
+			"url":               reportedSecret(d, "slack_config.0.url", config.URL),
+			"default_recipient": config.DefaultRecipient,
+		}}
+	}
+	if config := n.SMTPConfig; config != nil {
+		configs["smtp_config"] = []interface{}{map[string]interface{}{
+			"host":              config.Host,
+			"port":              int(config.Port),
+			"tls":               config.TLS,
+			"username":          config.Username,
+			"password":          reportedSecret(d, "smtp_config.0.password", config.Password),
+			"sender":            config.Sender,
+			"default_recipient": config.DefaultRecipient,
+		}}
+	}
+	if config := n.PagerdutyConfig; config != nil {
+		configs["pagerduty_config"] = []interface{}{map[string]interface{}{
+			"service_key": reportedSecret(d, "pagerduty_config.0.service_key", config.ServiceKey),
+		}}
+	}
+	if config := n.WebhookConfig; config != nil {
+		configs["webhook_config"] = []interface{}{map[string]interface{}{
+			"url": reportedSecret(d, "webhook_config.0.url", config.URL),
+		}}
+	}
+	if config := n.WechatConfig; config != nil {
+		configs["wechat_config"] = []interface{}{map[string]interface{}{
+			"corp_id":           config.CorpID,
+			"agent":             config.Agent,
+			"secret":            reportedSecret(d, "wechat_config.0.secret", config.Secret),
+			"recipient_type":    config.RecipientType,
+			"default_recipient": config.DefaultRecipient,
+		}}
+	}
+	for k, v := range configs {
+		if err := d.Set(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// testNotifier sends a test notification with the given notifier configuration. The notifier does not have to
+// exist, so that a failing test does not leave a broken notifier behind.
+func testNotifier(c *client.Client, n *notifier) error {
+	collection, err := c.Notifier.List(&types.ListOpts{
+		Filters: map[string]interface{}{
+			"clusterId": n.ClusterID,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return c.Ops.DoCollectionAction(client.NotifierType, "send", &collection.Collection, &notification{
+		Notification: client.Notification{
+			Message:         fmt.Sprintf("Test notification of notifier \"%s\"", n.Name),
+			SlackConfig:     n.SlackConfig,
+			SMTPConfig:      n.SMTPConfig,
+			PagerdutyConfig: n.PagerdutyConfig,
+			WebhookConfig:   n.WebhookConfig,
+		},
+		WechatConfig: n.WechatConfig,
+	}, nil)
+}
+
+func resourceNotifierCreate(d *schema.ResourceData, m interface{}) error {
+	rancher := m.(Config).Rancher()
+
+	n, err := expandNotifier(d)
+	if err != nil {
+		return err
+	}
+	if d.Get("test_on_create").(bool) {
+		if err := testNotifier(rancher, n); err != nil {
+			return fmt.Errorf("unable to send test notification of notifier \"%s\": %v", n.Name, err)
+		}
+	}
+
+	newNotifier := &notifier{}
+	if err := rancher.Create(client.NotifierType, n, newNotifier); err != nil {
+		return err
+	}
+	d.SetId(newNotifier.ID)
+
+	return resourceNotifierRead(d, m)
+}
+
+func resourceNotifierRead(d *schema.ResourceData, m interface{}) error {
+	rancher := m.(Config).Rancher()
+
+	n, err := notifierByID(rancher, d.Id())
+
+	if err != nil {
+		return err
+	} else if n == nil {
+		// If the notifier DOES NOT EXIST, it has probably already been deleted. Time to update the state...
+		d.SetId("")
+		return nil
+	}
+	d.Set("cluster_id", n.ClusterID)
+	d.Set("name", n.Name)
+	d.Set("description", n.Description)
+	if err := flattenNotifierConfigs(d, n); err != nil {
+		return err
+	}
+	d.Set("uuid", n.UUID)
+	return nil
+}
+
+func resourceNotifierUpdate(d *schema.ResourceData, m interface{}) error {
+	rancher := m.(Config).Rancher()
+
+	id := d.Id()
+
+	n, err := notifierByID(rancher, id)
+	if err != nil {
+		return err
+	}
+	if n == nil {
+		return fmt.Errorf("notifier with ID \"%s\" could not be found", id)
+	}
+
+	updated, err := expandNotifier(d)
+	if err != nil {
+		return err
+	}
+	// Configurations which are no longer given have to be removed explicitly, so we always send all of them.
+	if err := rancher.Update(client.NotifierType, &n.Resource, map[string]interface{}{
+		"name":            updated.Name,
+		"description":     updated.Description,
+		"slackConfig":     updated.SlackConfig,
+		"smtpConfig":      updated.SMTPConfig,
+		"pagerdutyConfig": updated.PagerdutyConfig,
+		"webhookConfig":   updated.WebhookConfig,
+		"wechatConfig":    updated.WechatConfig,
+	}, nil); err != nil {
+		return err
+	}
+
+	return resourceNotifierRead(d, m)
+}
+
+func resourceNotifierDelete(d *schema.ResourceData, m interface{}) error {
+	rancher := m.(Config).Rancher()
+
+	n, err := notifierByID(rancher, d.Id())
+	if err != nil {
+		return err
+	}
+	if n == nil {
+		// If the notifier DOES NOT EXIST, it has probably already been deleted. Nothing to do for us here...
+		return nil
+	}
+	return rancher.Ops.DoResourceDelete(client.NotifierType, &n.Resource)
+}
+
+func resourceNotifierExists(d *schema.ResourceData, m interface{}) (bool, error) {
+	rancher := m.(Config).Rancher()
+
+	n, err := notifierByID(rancher, d.Id())
+	if err != nil {
+		if _, isAPIError := err.(*clientbase.APIError); isAPIError && err.(*clientbase.APIError).StatusCode == 404 {
+			// Ignore 404 errors...
+			return false, nil
+		}
+	}
+	return n != nil, nil
+}
+
+func resourceNotifierState(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if err := resourceNotifierRead(d, m); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceNotifier() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNotifierCreate,
+		Read:   resourceNotifierRead,
+		Update: resourceNotifierUpdate,
+		Delete: resourceNotifierDelete,
+		Exists: resourceNotifierExists,
+		Importer: &schema.ResourceImporter{
+			State: resourceNotifierState,
+		},
+		Schema: map[string]*schema.Schema{
+			"cluster_id": {
+				Description: "ID of the cluster the notifier belongs to",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"name": {
+				Description: "Name of the notifier",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"description": {
+				Description: "Description of the notifier",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"slack_config": {
+				Description:   "Sends notifications to a Slack channel",
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				ConflictsWith: conflictingAttributes(notifierConfigs, "slack_config"),
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"url": {
+							Description: "URL of the incoming webhook of Slack",
+							Type:        schema.TypeString,
+							Required:    true,
+							Sensitive:   true,
+						},
+						"default_recipient": {
+							Description: "Channel or user notifications are sent to (e.g. \"#alerts\" or \"@jdoe\")",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+					},
+				},
+			},
+			"smtp_config": {
+				Description:   "Sends notifications via email",
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				ConflictsWith: conflictingAttributes(notifierConfigs, "smtp_config"),
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"host": {
+							Description: "Host name of the SMTP server",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"port": {
+							Description: "Port of the SMTP server",
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Default:     587,
+						},
+						"tls": {
+							Description: "Whether to connect to the SMTP server via TLS",
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+						},
+						"username": {
+							Description: "Username to authenticate with at the SMTP server",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"password": {
+							Description: "Password to authenticate with at the SMTP server",
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+						},
+						"sender": {
+							Description: "Email address notifications are sent from",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"default_recipient": {
+							Description: "Email address notifications are sent to",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+					},
+				},
+			},
+			"pagerduty_config": {
+				Description:   "Sends notifications to a PagerDuty service",
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				ConflictsWith: conflictingAttributes(notifierConfigs, "pagerduty_config"),
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"service_key": {
+							Description: "Integration key of the PagerDuty service",
+							Type:        schema.TypeString,
+							Required:    true,
+							Sensitive:   true,
+						},
+					},
+				},
+			},
+			"webhook_config": {
+				Description:   "Sends notifications to a webhook",
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				ConflictsWith: conflictingAttributes(notifierConfigs, "webhook_config"),
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"url": {
+							Description: "URL of the webhook",
+							Type:        schema.TypeString,
+							Required:    true,
+							Sensitive:   true,
+						},
+					},
+				},
+			},
+			"wechat_config": {
+				Description:   "Sends notifications via WeChat",
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				ConflictsWith: conflictingAttributes(notifierConfigs, "wechat_config"),
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"corp_id": {
+							Description: "ID of the WeChat corporation",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"agent": {
+							Description: "ID of the WeChat application notifications are sent by",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"secret": {
+							Description: "Secret of the WeChat application",
+							Type:        schema.TypeString,
+							Required:    true,
+							Sensitive:   true,
+						},
+						"recipient_type": {
+							Description:  "Type of the default recipient (\"party\", \"tag\" or \"user\")",
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "party",
+							ValidateFunc: validation.StringInSlice([]string{"party", "tag", "user"}, false),
+						},
+						"default_recipient": {
+							Description: "ID of the party, tag or user notifications are sent to",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+					},
+				},
+			},
+			"test_on_create": {
+				Description: "Whether to send a test notification before creating the notifier",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"uuid": {
+				Description: "UUID of the notifier as reported by the Rancher API",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
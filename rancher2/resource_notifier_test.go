@@ -0,0 +1,71 @@
+package rancher2
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/rancher/types/client/management/v3"
+)
+
+func TestExpandNotifier(t *testing.T) {
+	tt := []struct {
+		raw         map[string]interface{}
+		errExpected bool
+	}{
+		{raw: map[string]interface{}{
+			"slack_config": []interface{}{map[string]interface{}{"url": "https://hooks.slack.com/services/T0/B0/x", "default_recipient": "#alerts"}},
+		}},
+		{raw: map[string]interface{}{
+			"wechat_config": []interface{}{map[string]interface{}{"corp_id": "corp", "agent": "1000002", "secret": "s3cr3t", "default_recipient": "1"}},
+		}},
+		{raw: map[string]interface{}{}, errExpected: true},
+		{raw: map[string]interface{}{
+			"pagerduty_config": []interface{}{map[string]interface{}{"service_key": "key"}},
+			"webhook_config":   []interface{}{map[string]interface{}{"url": "https://example.com/hook"}},
+		}, errExpected: true},
+	}
+	for i, td := range tt {
+		td.raw["cluster_id"] = "c-abcde"
+		td.raw["name"] = "alerts"
+		d := schema.TestResourceDataRaw(t, resourceNotifier().Schema, td.raw)
+		n, err := expandNotifier(d)
+		if (td.errExpected && err == nil) || (!td.errExpected && err != nil) {
+			t.Errorf("unexpected error result for notifier %d: %v", i, err)
+			continue
+		}
+		if err == nil && (n.ClusterID != "c-abcde" || n.Name != "alerts") {
+			t.Errorf("unexpected notifier %d: %+v", i, n)
+		}
+	}
+}
+
+func TestFlattenNotifierConfigsKeepsSecrets(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceNotifier().Schema, map[string]interface{}{
+		"cluster_id": "c-abcde",
+		"name":       "alerts",
+		"smtp_config": []interface{}{map[string]interface{}{
+			"host":              "smtp.example.com",
+			"password":          "s3cr3t",
+			"sender":            "rancher@example.com",
+			"default_recipient": "ops@example.com",
+		}},
+	})
+	n := &notifier{Notifier: client.Notifier{SMTPConfig: &client.SMTPConfig{
+		Host:             "smtp.example.com",
+		Port:             25,
+		Sender:           "rancher@example.com",
+		DefaultRecipient: "ops@example.com",
+	}}}
+	if err := flattenNotifierConfigs(d, n); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if password := d.Get("smtp_config.0.password").(string); password != "s3cr3t" {
+		t.Errorf("unexpected password: %s", password)
+	}
+	if port := d.Get("smtp_config.0.port").(int); port != 25 {
+		t.Errorf("unexpected port: %d", port)
+	}
+	if configs := d.Get("slack_config").([]interface{}); len(configs) != 0 {
+		t.Errorf("unexpected Slack configuration: %+v", configs)
+	}
+}
@@ -0,0 +1,262 @@
+package rancher2
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/rancher/norman/clientbase"
+	"github.com/rancher/types/client/management/v3"
+)
+
+// projectAlertTargets lists the attributes defining what a project alert watches. Exactly one of them has to
+// be given.
+var projectAlertTargets = []string{"workload", "pod"}
+
+// expandProjectAlert converts the configured project alert to its API representation.
+func expandProjectAlert(c *client.Client, d *schema.ResourceData) (*client.ProjectAlert, error) {
+	recipients, err := expandAlertRecipients(c, d.Get("recipient"))
+	if err != nil {
+		return nil, err
+	}
+	alert := &client.ProjectAlert{
+		ProjectID:             d.Get("project_id").(string),
+		Name:                  d.Get("name").(string),
+		Description:           d.Get("description").(string),
+		Severity:              d.Get("severity").(string),
+		InitialWaitSeconds:    int64(d.Get("initial_wait_seconds").(int)),
+		RepeatIntervalSeconds: int64(d.Get("repeat_interval_seconds").(int)),
+		Recipients:            recipients,
+	}
+
+	target, config, err := alertTarget(d, projectAlertTargets)
+	if err != nil {
+		return nil, err
+	}
+	switch target {
+	case "workload":
+		alert.TargetWorkload = &client.TargetWorkload{
+			WorkloadID:          config["workload_id"].(string),
+			Selector:            expandStringMap(config["selector"]),
+			AvailablePercentage: int64(config["available_percentage"].(int)),
+		}
+		if (alert.TargetWorkload.WorkloadID == "") == (len(alert.TargetWorkload.Selector) == 0) {
+			return nil, fmt.Errorf("exactly one of workload_id and selector has to be given for workload targets")
+		}
+	case "pod":
+		alert.TargetPod = &client.TargetPod{
+			PodID:                  config["pod_id"].(string),
+			Condition:              config["condition"].(string),
+			RestartTimes:           int64(config["restart_times"].(int)),
+			RestartIntervalSeconds: int64(config["restart_interval_seconds"].(int)),
+		}
+	}
+	return alert, nil
+}
+
+// flattenProjectAlertTargets updates the terraform state with the target of the given project alert.
+func flattenProjectAlertTargets(d *schema.ResourceData, alert *client.ProjectAlert) error {
+	targets := map[string][]interface{}{}
+	for _, k := range projectAlertTargets {
+		targets[k] = []interface{}{}
+	}
+	if t := alert.TargetWorkload; t != nil {
+		targets["workload"] = []interface{}{map[string]interface{}{
+			"workload_id":          t.WorkloadID,
+			"selector":             t.Selector,
+			"available_percentage": int(t.AvailablePercentage),
+		}}
+	}
+	if t := alert.TargetPod; t != nil {
+		targets["pod"] = []interface{}{map[string]interface{}{
+			"pod_id":                   t.PodID,
+			"condition":                t.Condition,
+			"restart_times":            int(t.RestartTimes),
+			"restart_interval_seconds": int(t.RestartIntervalSeconds),
+		}}
+	}
+	for k, v := range targets {
+		if err := d.Set(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func resourceProjectAlertCreate(d *schema.ResourceData, m interface{}) error {
+	rancher := m.(Config).Rancher()
+
+	alert, err := expandProjectAlert(rancher, d)
+	if err != nil {
+		return err
+	}
+	newAlert, err := rancher.ProjectAlert.Create(alert)
+	if err != nil {
+		return err
+	}
+	d.SetId(newAlert.ID)
+
+	return resourceProjectAlertRead(d, m)
+}
+
+func resourceProjectAlertRead(d *schema.ResourceData, m interface{}) error {
+	rancher := m.(Config).Rancher()
+
+	alert, err := rancher.ProjectAlert.ByID(d.Id())
+
+	if err != nil {
+		return err
+	} else if alert == nil {
+		// If the alert DOES NOT EXIST, it has probably already been deleted. Time to update the state...
+		d.SetId("")
+		return nil
+	}
+	d.Set("project_id", alert.ProjectID)
+	d.Set("name", alert.Name)
+	d.Set("description", alert.Description)
+	d.Set("severity", alert.Severity)
+	d.Set("initial_wait_seconds", int(alert.InitialWaitSeconds))
+	d.Set("repeat_interval_seconds", int(alert.RepeatIntervalSeconds))
+	if err := d.Set("recipient", flattenAlertRecipients(alert.Recipients)); err != nil {
+		return err
+	}
+	if err := flattenProjectAlertTargets(d, alert); err != nil {
+		return err
+	}
+	d.Set("state", alert.AlertState)
+	d.Set("uuid", alert.UUID)
+	return nil
+}
+
+func resourceProjectAlertUpdate(d *schema.ResourceData, m interface{}) error {
+	rancher := m.(Config).Rancher()
+
+	id := d.Id()
+
+	alert, err := rancher.ProjectAlert.ByID(id)
+	if err != nil {
+		return err
+	}
+	if alert == nil {
+		return fmt.Errorf("project alert with ID \"%s\" could not be found", id)
+	}
+
+	updated, err := expandProjectAlert(rancher, d)
+	if err != nil {
+		return err
+	}
+	// Targets which are no longer given have to be removed explicitly, so we always send all of them.
+	if _, err := rancher.ProjectAlert.Update(alert, map[string]interface{}{
+		"name":                  updated.Name,
+		"description":           updated.Description,
+		"severity":              updated.Severity,
+		"initialWaitSeconds":    updated.InitialWaitSeconds,
+		"repeatIntervalSeconds": updated.RepeatIntervalSeconds,
+		"recipients":            updated.Recipients,
+		"targetWorkload":        updated.TargetWorkload,
+		"targetPod":             updated.TargetPod,
+	}); err != nil {
+		return err
+	}
+
+	return resourceProjectAlertRead(d, m)
+}
+
+func resourceProjectAlertDelete(d *schema.ResourceData, m interface{}) error {
+	rancher := m.(Config).Rancher()
+
+	alert, err := rancher.ProjectAlert.ByID(d.Id())
+	if err != nil {
+		return err
+	}
+	if alert == nil {
+		// If the alert DOES NOT EXIST, it has probably already been deleted. Nothing to do for us here...
+		return nil
+	}
+	return rancher.ProjectAlert.Delete(alert)
+}
+
+func resourceProjectAlertExists(d *schema.ResourceData, m interface{}) (bool, error) {
+	rancher := m.(Config).Rancher()
+
+	alert, err := rancher.ProjectAlert.ByID(d.Id())
+	if err != nil {
+		if _, isAPIError := err.(*clientbase.APIError); isAPIError && err.(*clientbase.APIError).StatusCode == 404 {
+			// Ignore 404 errors...
+			return false, nil
+		}
+	}
+	return alert != nil, nil
+}
+
+func resourceProjectAlertState(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if err := resourceProjectAlertRead(d, m); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceProjectAlert() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceProjectAlertCreate,
+		Read:   resourceProjectAlertRead,
+		Update: resourceProjectAlertUpdate,
+		Delete: resourceProjectAlertDelete,
+		Exists: resourceProjectAlertExists,
+		Importer: &schema.ResourceImporter{
+			State: resourceProjectAlertState,
+		},
+		Schema: mergeSchemas(alertSchema(), map[string]*schema.Schema{
+			"project_id": {
+				Description: "ID of the project the alert belongs to",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"workload": alertTargetSchema("Raises the alert if too few pods of workloads are available", projectAlertTargets, "workload", map[string]*schema.Schema{
+				"workload_id": {
+					Description: "ID of the workload to watch",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"selector": {
+					Description: "Labels of the workloads to watch",
+					Type:        schema.TypeMap,
+					Optional:    true,
+				},
+				"available_percentage": {
+					Description: "Percentage of available pods below which the alert is raised",
+					Type:        schema.TypeInt,
+					Optional:    true,
+					Default:     70,
+				},
+			}),
+			"pod": alertTargetSchema("Raises the alert if a pod meets the condition", projectAlertTargets, "pod", map[string]*schema.Schema{
+				"pod_id": {
+					Description: "ID of the pod to watch",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"condition": {
+					Description:  "Condition of the pod that raises the alert (\"notrunning\", \"notscheduled\" or \"restarts\")",
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      "notrunning",
+					ValidateFunc: validation.StringInSlice([]string{"notrunning", "notscheduled", "restarts"}, false),
+				},
+				"restart_times": {
+					Description: "Number of restarts that raises the alert if the condition is \"restarts\"",
+					Type:        schema.TypeInt,
+					Optional:    true,
+					Default:     3,
+				},
+				"restart_interval_seconds": {
+					Description: "Seconds the restarts are counted in if the condition is \"restarts\"",
+					Type:        schema.TypeInt,
+					Optional:    true,
+					Default:     300,
+				},
+			}),
+		}),
+	}
+}
@@ -0,0 +1,211 @@
+package rancher2
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/rancher/norman/clientbase"
+	"github.com/rancher/norman/types"
+	"github.com/rancher/types/client/management/v3"
+)
+
+// projectLogging is the API type of the logging of projects.
+type projectLogging struct {
+	types.Resource
+	loggingTargets
+	Name                string            `json:"name,omitempty" yaml:"name,omitempty"`
+	ProjectID           string            `json:"projectId,omitempty" yaml:"projectId,omitempty"`
+	OutputFlushInterval int64             `json:"outputFlushInterval,omitempty" yaml:"outputFlushInterval,omitempty"`
+	OutputTags          map[string]string `json:"outputTags,omitempty" yaml:"outputTags,omitempty"`
+	State               string            `json:"state,omitempty" yaml:"state,omitempty"`
+	UUID                string            `json:"uuid,omitempty" yaml:"uuid,omitempty"`
+}
+
+// projectLoggingByID returns the logging of a project with the given ID if it exists.
+func projectLoggingByID(c *client.Client, id string) (*projectLogging, error) {
+	l := &projectLogging{}
+	if err := c.ByID(client.ProjectLoggingType, id, l); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// projectClusterID returns the ID of the cluster of the project with the given ID (e.g. "c-abcde:p-fghij").
+func projectClusterID(projectID string) (string, error) {
+	parts := strings.SplitN(projectID, ":", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", fmt.Errorf("invalid project ID \"%s\": expected \"<cluster ID>:<project ID>\"", projectID)
+	}
+	return parts[0], nil
+}
+
+// validateProjectLoggingCluster checks that the cluster of the project with the given ID exists and is
+// active, as Rancher is unable to deploy the log forwarder otherwise.
+func validateProjectLoggingCluster(c *client.Client, projectID string) error {
+	clusterID, err := projectClusterID(projectID)
+	if err != nil {
+		return err
+	}
+	cluster, err := c.Cluster.ByID(clusterID)
+	if err != nil {
+		if apiError, isAPIError := err.(*clientbase.APIError); isAPIError && apiError.StatusCode == 404 {
+			return fmt.Errorf("cluster \"%s\" of project \"%s\" does not exist", clusterID, projectID)
+		}
+		return err
+	}
+	if cluster.State != "active" {
+		return fmt.Errorf("cluster \"%s\" of project \"%s\" is not active (state: \"%s\")", clusterID, projectID, cluster.State)
+	}
+	return nil
+}
+
+// expandProjectLogging converts the configured logging of a project to its API representation.
+func expandProjectLogging(d *schema.ResourceData) (*projectLogging, error) {
+	targets, err := expandLoggingTargets(d)
+	if err != nil {
+		return nil, err
+	}
+	return &projectLogging{
+		loggingTargets:      *targets,
+		Name:                d.Get("name").(string),
+		ProjectID:           d.Get("project_id").(string),
+		OutputFlushInterval: int64(d.Get("output_flush_interval").(int)),
+		OutputTags:          expandStringMap(d.Get("output_tags")),
+	}, nil
+}
+
+func resourceProjectLoggingCreate(d *schema.ResourceData, m interface{}) error {
+	rancher := m.(Config).Rancher()
+
+	l, err := expandProjectLogging(d)
+	if err != nil {
+		return err
+	}
+	if err := validateProjectLoggingCluster(rancher, l.ProjectID); err != nil {
+		return err
+	}
+	newLogging := &projectLogging{}
+	if err := rancher.Create(client.ProjectLoggingType, l, newLogging); err != nil {
+		return err
+	}
+	d.SetId(newLogging.ID)
+
+	return resourceProjectLoggingRead(d, m)
+}
+
+func resourceProjectLoggingRead(d *schema.ResourceData, m interface{}) error {
+	rancher := m.(Config).Rancher()
+
+	l, err := projectLoggingByID(rancher, d.Id())
+
+	if err != nil {
+		return err
+	} else if l == nil {
+		// If the logging DOES NOT EXIST, it has probably already been deleted. Time to update the state...
+		d.SetId("")
+		return nil
+	}
+	d.Set("project_id", l.ProjectID)
+	d.Set("name", l.Name)
+	d.Set("output_flush_interval", int(l.OutputFlushInterval))
+	d.Set("output_tags", l.OutputTags)
+	if err := flattenLoggingTargets(d, &l.loggingTargets); err != nil {
+		return err
+	}
+	d.Set("state", l.State)
+	d.Set("uuid", l.UUID)
+	return nil
+}
+
+func resourceProjectLoggingUpdate(d *schema.ResourceData, m interface{}) error {
+	rancher := m.(Config).Rancher()
+
+	id := d.Id()
+
+	l, err := projectLoggingByID(rancher, id)
+	if err != nil {
+		return err
+	}
+	if l == nil {
+		return fmt.Errorf("project logging with ID \"%s\" could not be found", id)
+	}
+
+	updated, err := expandProjectLogging(d)
+	if err != nil {
+		return err
+	}
+	if err := validateProjectLoggingCluster(rancher, updated.ProjectID); err != nil {
+		return err
+	}
+	updates := updated.loggingTargets.updates()
+	updates["name"] = updated.Name
+	updates["outputFlushInterval"] = updated.OutputFlushInterval
+	updates["outputTags"] = updated.OutputTags
+	if err := rancher.Update(client.ProjectLoggingType, &l.Resource, updates, nil); err != nil {
+		return err
+	}
+
+	return resourceProjectLoggingRead(d, m)
+}
+
+func resourceProjectLoggingDelete(d *schema.ResourceData, m interface{}) error {
+	rancher := m.(Config).Rancher()
+
+	l, err := projectLoggingByID(rancher, d.Id())
+	if err != nil {
+		return err
+	}
+	if l == nil {
+		// If the logging DOES NOT EXIST, it has probably already been deleted. Nothing to do for us here...
+		return nil
+	}
+	return rancher.Ops.DoResourceDelete(client.ProjectLoggingType, &l.Resource)
+}
+
+func resourceProjectLoggingExists(d *schema.ResourceData, m interface{}) (bool, error) {
+	rancher := m.(Config).Rancher()
+
+	l, err := projectLoggingByID(rancher, d.Id())
+	if err != nil {
+		if _, isAPIError := err.(*clientbase.APIError); isAPIError && err.(*clientbase.APIError).StatusCode == 404 {
+			// Ignore 404 errors...
+			return false, nil
+		}
+	}
+	return l != nil, nil
+}
+
+func resourceProjectLoggingState(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if err := resourceProjectLoggingRead(d, m); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceProjectLogging() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceProjectLoggingCreate,
+		Read:   resourceProjectLoggingRead,
+		Update: resourceProjectLoggingUpdate,
+		Delete: resourceProjectLoggingDelete,
+		Exists: resourceProjectLoggingExists,
+		Importer: &schema.ResourceImporter{
+			State: resourceProjectLoggingState,
+		},
+		Schema: mergeSchemas(loggingSchema(), map[string]*schema.Schema{
+			"project_id": {
+				Description: "ID of the project whose logs are forwarded (e.g. \"c-abcde:p-fghij\")",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"name": {
+				Description: "Name of the logging configuration",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+		}),
+	}
+}
@@ -0,0 +1,29 @@
+package rancher2
+
+import (
+	"testing"
+)
+
+func TestProjectClusterID(t *testing.T) {
+	tt := []struct {
+		projectID   string
+		expected    string
+		errExpected bool
+	}{
+		{projectID: "c-abcde:p-fghij", expected: "c-abcde"},
+		{projectID: "local:p-fghij", expected: "local"},
+		{projectID: "p-fghij", errExpected: true},
+		{projectID: ":p-fghij", errExpected: true},
+		{projectID: "c-abcde:", errExpected: true},
+	}
+	for _, td := range tt {
+		clusterID, err := projectClusterID(td.projectID)
+		if (td.errExpected && err == nil) || (!td.errExpected && err != nil) {
+			t.Errorf("unexpected error result for project ID \"%s\": %v", td.projectID, err)
+			continue
+		}
+		if clusterID != td.expected {
+			t.Errorf("unexpected cluster ID of project \"%s\": %s", td.projectID, clusterID)
+		}
+	}
+}
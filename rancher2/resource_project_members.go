@@ -0,0 +1,302 @@
+package rancher2
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/rancher/norman/types"
+	"github.com/rancher/types/client/management/v3"
+)
+
+// projectOwnerRoleTemplateID is the ID of the built-in role template that grants ownership of a project.
+const projectOwnerRoleTemplateID = "project-owner"
+
+// projectRoleTemplateBindingsByProject returns all role template bindings of the given project.
+func projectRoleTemplateBindingsByProject(c *client.Client, projectID string) ([]client.ProjectRoleTemplateBinding, error) {
+	collection, err := c.ProjectRoleTemplateBinding.List(&types.ListOpts{
+		Filters: map[string]interface{}{
+			"projectId": projectID,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	bindings := make([]client.ProjectRoleTemplateBinding, 0, len(collection.Data))
+	for collection != nil {
+		for _, binding := range collection.Data {
+			// Rancher should already have filtered by project, but better safe than sorry...
+			if binding.ProjectID == projectID {
+				bindings = append(bindings, binding)
+			}
+		}
+		if collection, err = collection.Next(); err != nil {
+			return nil, err
+		}
+	}
+	return bindings, nil
+}
+
+// projectMembersBindings returns the bindings of the "binding" set of a rancher2_project_members resource.
+func projectMembersBindings(d *schema.ResourceData, projectID string) []client.ProjectRoleTemplateBinding {
+	set := d.Get("binding").(*schema.Set).List()
+	bindings := make([]client.ProjectRoleTemplateBinding, 0, len(set))
+	for _, b := range set {
+		binding := b.(map[string]interface{})
+		bindings = append(bindings, client.ProjectRoleTemplateBinding{
+			ProjectID:        projectID,
+			RoleTemplateID:   binding["role_template_id"].(string),
+			UserID:           binding["user_id"].(string),
+			UserPrincipalID:  binding["user_principal_id"].(string),
+			GroupPrincipalID: binding["group_principal_id"].(string),
+		})
+	}
+	return bindings
+}
+
+// projectMembersExcluded checks whether the subject of the given binding has been excluded from being managed.
+func projectMembersExcluded(d *schema.ResourceData, b *client.ProjectRoleTemplateBinding) bool {
+	excluded := d.Get("exclude_principals").(*schema.Set)
+	for _, subject := range []string{b.UserID, b.UserPrincipalID, b.GroupPrincipalID} {
+		if subject != "" && excluded.Contains(subject) {
+			return true
+		}
+	}
+	return false
+}
+
+// projectMembersProtected checks whether the given binding makes the user the provider is authenticated as
+// an owner of the project. We never remove such a binding, otherwise we'd lock ourselves out.
+func projectMembersProtected(caller *client.User, b *client.ProjectRoleTemplateBinding) bool {
+	if b.RoleTemplateID != projectOwnerRoleTemplateID {
+		return false
+	}
+	if b.UserID == caller.ID {
+		return true
+	}
+	for _, principalID := range caller.PrincipalIDs {
+		if b.UserPrincipalID == principalID {
+			return true
+		}
+	}
+	return false
+}
+
+// projectMembersFind returns the first existing binding that matches the wanted one.
+func projectMembersFind(bindings []client.ProjectRoleTemplateBinding, wanted *client.ProjectRoleTemplateBinding) *client.ProjectRoleTemplateBinding {
+	for i := range bindings {
+		if projectRoleTemplateBindingMatches(&bindings[i], wanted) {
+			return &bindings[i]
+		}
+	}
+	return nil
+}
+
+// projectMembersMatchConfigured checks whether the given existing binding matches any of the configured ones.
+func projectMembersMatchConfigured(configured []client.ProjectRoleTemplateBinding, existing *client.ProjectRoleTemplateBinding) bool {
+	for i := range configured {
+		if projectRoleTemplateBindingMatches(existing, &configured[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+func resourceProjectMembersCreate(d *schema.ResourceData, m interface{}) error {
+	d.SetId(d.Get("project_id").(string))
+	return resourceProjectMembersUpdate(d, m)
+}
+
+func resourceProjectMembersRead(d *schema.ResourceData, m interface{}) error {
+	rancher := m.(Config).Rancher()
+
+	projectID := d.Id()
+	project, err := rancher.Project.ByID(projectID)
+	if err != nil {
+		return err
+	} else if project == nil {
+		// If the project DOES NOT EXIST, it has probably already been deleted. Time to update the state...
+		d.SetId("")
+		return nil
+	}
+
+	caller, err := currentUser(rancher)
+	if err != nil {
+		return err
+	}
+	existing, err := projectRoleTemplateBindingsByProject(rancher, projectID)
+	if err != nil {
+		return err
+	}
+	configured := projectMembersBindings(d, projectID)
+
+	bindings := make([]interface{}, 0, len(existing))
+	for i := range existing {
+		binding := &existing[i]
+		if projectMembersExcluded(d, binding) {
+			continue
+		}
+		// If a binding has been configured that matches the existing one, we report it the way it has been
+		// configured. Otherwise we report everything Rancher knows about the subject of the binding, so that
+		// the binding shows up as one to be removed.
+		reported := binding
+		for j := range configured {
+			if projectRoleTemplateBindingMatches(binding, &configured[j]) {
+				reported = &configured[j]
+				break
+			}
+		}
+		if reported == binding && projectMembersProtected(caller, binding) {
+			// The project owner binding of the caller is never removed, so there is no point in reporting it
+			// unless it has been configured explicitly.
+			continue
+		}
+		bindings = append(bindings, map[string]interface{}{
+			"role_template_id":   reported.RoleTemplateID,
+			"user_id":            reported.UserID,
+			"user_principal_id":  reported.UserPrincipalID,
+			"group_principal_id": reported.GroupPrincipalID,
+		})
+	}
+
+	d.Set("project_id", projectID)
+	return d.Set("binding", bindings)
+}
+
+func resourceProjectMembersUpdate(d *schema.ResourceData, m interface{}) error {
+	rancher := m.(Config).Rancher()
+
+	projectID := d.Id()
+
+	caller, err := currentUser(rancher)
+	if err != nil {
+		return err
+	}
+	existing, err := projectRoleTemplateBindingsByProject(rancher, projectID)
+	if err != nil {
+		return err
+	}
+	configured := projectMembersBindings(d, projectID)
+
+	// Create all bindings that have been configured but do not exist yet...
+	for i := range configured {
+		wanted := &configured[i]
+		if wanted.UserID == "" && wanted.UserPrincipalID == "" && wanted.GroupPrincipalID == "" {
+			return fmt.Errorf("binding of role template \"%s\" requires one of user_id, user_principal_id or group_principal_id", wanted.RoleTemplateID)
+		}
+		if projectMembersFind(existing, wanted) != nil {
+			continue
+		}
+		if _, err := rancher.ProjectRoleTemplateBinding.Create(wanted); err != nil {
+			return fmt.Errorf("unable to bind role template \"%s\" to %s: %v", wanted.RoleTemplateID, projectRoleTemplateBindingSubject(wanted), err)
+		}
+	}
+
+	// ...and delete all bindings that exist but have not been configured.
+	for i := range existing {
+		binding := &existing[i]
+		if projectMembersMatchConfigured(configured, binding) {
+			continue
+		}
+		if projectMembersExcluded(d, binding) || projectMembersProtected(caller, binding) {
+			continue
+		}
+		if err := rancher.ProjectRoleTemplateBinding.Delete(binding); err != nil {
+			return fmt.Errorf("unable to remove binding \"%s\" of role template \"%s\": %v", binding.ID, binding.RoleTemplateID, err)
+		}
+	}
+
+	return resourceProjectMembersRead(d, m)
+}
+
+func resourceProjectMembersDelete(d *schema.ResourceData, m interface{}) error {
+	rancher := m.(Config).Rancher()
+
+	projectID := d.Id()
+
+	caller, err := currentUser(rancher)
+	if err != nil {
+		return err
+	}
+	existing, err := projectRoleTemplateBindingsByProject(rancher, projectID)
+	if err != nil {
+		return err
+	}
+
+	// We only remove the bindings that we have been managing and leave everything else as it is.
+	managed := projectMembersBindings(d, projectID)
+	for i := range existing {
+		binding := &existing[i]
+		if !projectMembersMatchConfigured(managed, binding) {
+			continue
+		}
+		if projectMembersExcluded(d, binding) || projectMembersProtected(caller, binding) {
+			continue
+		}
+		if err := rancher.ProjectRoleTemplateBinding.Delete(binding); err != nil {
+			return fmt.Errorf("unable to remove binding \"%s\" of role template \"%s\": %v", binding.ID, binding.RoleTemplateID, err)
+		}
+	}
+	return nil
+}
+
+func resourceProjectMembersState(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if err := resourceProjectMembersRead(d, m); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceProjectMembers() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceProjectMembersCreate,
+		Read:   resourceProjectMembersRead,
+		Update: resourceProjectMembersUpdate,
+		Delete: resourceProjectMembersDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceProjectMembersState,
+		},
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Description: "ID of the project whose members shall be managed",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"binding": {
+				Description: "Complete set of role template bindings of the project",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"role_template_id": {
+							Description: "ID of the role template to bind (e.g. \"project-member\" or \"read-only\")",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"user_id": {
+							Description: "ID of the Rancher user the role template shall be bound to",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"user_principal_id": {
+							Description: "ID of the user principal the role template shall be bound to",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"group_principal_id": {
+							Description: "ID of the group principal the role template shall be bound to",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"exclude_principals": {
+				Description: "User IDs or principal IDs whose bindings shall neither be reported nor removed",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
@@ -0,0 +1,142 @@
+package rancher2
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/rancher/norman/types"
+	"github.com/rancher/types/client/management/v3"
+)
+
+func TestProjectRoleTemplateBindingMatches(t *testing.T) {
+	existing := &client.ProjectRoleTemplateBinding{
+		RoleTemplateID:  "project-member",
+		UserID:          "u-abcde",
+		UserPrincipalID: "local://u-abcde",
+	}
+	tt := []struct {
+		wanted   client.ProjectRoleTemplateBinding
+		expected bool
+	}{
+		{
+			wanted:   client.ProjectRoleTemplateBinding{RoleTemplateID: "project-member", UserID: "u-abcde"},
+			expected: true,
+		},
+		{
+			wanted:   client.ProjectRoleTemplateBinding{RoleTemplateID: "project-member", UserPrincipalID: "local://u-abcde"},
+			expected: true,
+		},
+		{
+			wanted:   client.ProjectRoleTemplateBinding{RoleTemplateID: "project-member", UserID: "u-abcde", UserPrincipalID: "local://u-abcde"},
+			expected: true,
+		},
+		{
+			// Different role template
+			wanted:   client.ProjectRoleTemplateBinding{RoleTemplateID: "read-only", UserID: "u-abcde"},
+			expected: false,
+		},
+		{
+			// Different user
+			wanted:   client.ProjectRoleTemplateBinding{RoleTemplateID: "project-member", UserID: "u-fghij"},
+			expected: false,
+		},
+		{
+			// One of the given subject attributes differs
+			wanted:   client.ProjectRoleTemplateBinding{RoleTemplateID: "project-member", UserID: "u-abcde", UserPrincipalID: "local://u-fghij"},
+			expected: false,
+		},
+		{
+			// Group bindings never match user bindings
+			wanted:   client.ProjectRoleTemplateBinding{RoleTemplateID: "project-member", GroupPrincipalID: "activedirectory_group://CN=Developers"},
+			expected: false,
+		},
+		{
+			// Bindings without subject match nothing
+			wanted:   client.ProjectRoleTemplateBinding{RoleTemplateID: "project-member"},
+			expected: false,
+		},
+	}
+	for _, td := range tt {
+		if matches := projectRoleTemplateBindingMatches(existing, &td.wanted); matches != td.expected {
+			t.Errorf("unexpected result for %+v: expected %v, got %v", td.wanted, td.expected, matches)
+		}
+	}
+}
+
+func TestProjectMembersProtected(t *testing.T) {
+	caller := &client.User{
+		Resource:     types.Resource{ID: "u-abcde"},
+		PrincipalIDs: []string{"local://u-abcde", "activedirectory_user://CN=Doe"},
+	}
+	tt := []struct {
+		binding  client.ProjectRoleTemplateBinding
+		expected bool
+	}{
+		{
+			binding:  client.ProjectRoleTemplateBinding{RoleTemplateID: projectOwnerRoleTemplateID, UserID: "u-abcde"},
+			expected: true,
+		},
+		{
+			binding:  client.ProjectRoleTemplateBinding{RoleTemplateID: projectOwnerRoleTemplateID, UserPrincipalID: "activedirectory_user://CN=Doe"},
+			expected: true,
+		},
+		{
+			// Only ownership is protected
+			binding:  client.ProjectRoleTemplateBinding{RoleTemplateID: "project-member", UserID: "u-abcde"},
+			expected: false,
+		},
+		{
+			// Ownership of other users is not protected
+			binding:  client.ProjectRoleTemplateBinding{RoleTemplateID: projectOwnerRoleTemplateID, UserID: "u-fghij"},
+			expected: false,
+		},
+		{
+			// Ownership granted via groups is not protected
+			binding:  client.ProjectRoleTemplateBinding{RoleTemplateID: projectOwnerRoleTemplateID, GroupPrincipalID: "activedirectory_group://CN=Doe"},
+			expected: false,
+		},
+	}
+	for _, td := range tt {
+		if protected := projectMembersProtected(caller, &td.binding); protected != td.expected {
+			t.Errorf("unexpected result for %+v: expected %v, got %v", td.binding, td.expected, protected)
+		}
+	}
+}
+
+func TestProjectMembersExcluded(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceProjectMembers().Schema, map[string]interface{}{
+		"project_id":         "c-abcde:p-fghij",
+		"exclude_principals": []interface{}{"u-abcde", "activedirectory_group://CN=Admins"},
+	})
+	tt := []struct {
+		binding  client.ProjectRoleTemplateBinding
+		expected bool
+	}{
+		{
+			binding:  client.ProjectRoleTemplateBinding{RoleTemplateID: "project-member", UserID: "u-abcde"},
+			expected: true,
+		},
+		{
+			binding:  client.ProjectRoleTemplateBinding{RoleTemplateID: "project-member", UserID: "u-abcde", UserPrincipalID: "local://u-abcde"},
+			expected: true,
+		},
+		{
+			binding:  client.ProjectRoleTemplateBinding{RoleTemplateID: "project-owner", GroupPrincipalID: "activedirectory_group://CN=Admins"},
+			expected: true,
+		},
+		{
+			binding:  client.ProjectRoleTemplateBinding{RoleTemplateID: "project-member", UserID: "u-fghij", UserPrincipalID: "local://u-fghij"},
+			expected: false,
+		},
+		{
+			// Empty subject attributes never match
+			binding:  client.ProjectRoleTemplateBinding{RoleTemplateID: "project-member"},
+			expected: false,
+		},
+	}
+	for _, td := range tt {
+		if excluded := projectMembersExcluded(d, &td.binding); excluded != td.expected {
+			t.Errorf("unexpected result for %+v: expected %v, got %v", td.binding, td.expected, excluded)
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package rancher2
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/rancher/norman/clientbase"
+	"github.com/rancher/norman/types"
+	"github.com/rancher/types/client/management/v3"
+)
+
+// projectRoleTemplateBindingSubjects lists the attributes that identify the subject (user or group) of a
+// project role template binding. Exactly one of them has to be given.
+var projectRoleTemplateBindingSubjects = []string{"user_id", "user_principal_id", "group_principal_id"}
+
+// projectRoleTemplateBindingFilters returns the API filters that match bindings of the given role template
+// in the given project for the given subject.
+func projectRoleTemplateBindingFilters(b *client.ProjectRoleTemplateBinding) map[string]interface{} {
+	filters := map[string]interface{}{
+		"projectId":      b.ProjectID,
+		"roleTemplateId": b.RoleTemplateID,
+	}
+	if b.UserID != "" {
+		filters["userId"] = b.UserID
+	}
+	if b.UserPrincipalID != "" {
+		filters["userPrincipalId"] = b.UserPrincipalID
+	}
+	if b.GroupPrincipalID != "" {
+		filters["groupPrincipalId"] = b.GroupPrincipalID
+	}
+	return filters
+}
+
+// projectRoleTemplateBindingMatches checks whether an existing binding grants the same role template to the
+// same subject as the wanted one. Bindings created via the UI may carry both, the user ID and the user
+// principal ID, so we only compare the subject attributes that have actually been given.
+func projectRoleTemplateBindingMatches(existing *client.ProjectRoleTemplateBinding, wanted *client.ProjectRoleTemplateBinding) bool {
+	if existing.RoleTemplateID != wanted.RoleTemplateID {
+		return false
+	}
+	if wanted.UserID == "" && wanted.UserPrincipalID == "" && wanted.GroupPrincipalID == "" {
+		return false
+	}
+	return (wanted.UserID == "" || existing.UserID == wanted.UserID) &&
+		(wanted.UserPrincipalID == "" || existing.UserPrincipalID == wanted.UserPrincipalID) &&
+		(wanted.GroupPrincipalID == "" || existing.GroupPrincipalID == wanted.GroupPrincipalID)
+}
+
+// projectRoleTemplateBindingBySubject returns an already existing binding that grants the same role template
+// in the same project to the same subject, if there is one.
+func projectRoleTemplateBindingBySubject(c *client.Client, b *client.ProjectRoleTemplateBinding) (*client.ProjectRoleTemplateBinding, error) {
+	bindings, err := c.ProjectRoleTemplateBinding.List(&types.ListOpts{
+		Filters: projectRoleTemplateBindingFilters(b),
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, binding := range bindings.Data {
+		if projectRoleTemplateBindingMatches(&binding, b) {
+			return &binding, nil
+		}
+	}
+	return nil, nil
+}
+
+// projectRoleTemplateBindingSubject returns a human-readable description of the subject of a binding.
+func projectRoleTemplateBindingSubject(b *client.ProjectRoleTemplateBinding) string {
+	switch {
+	case b.GroupPrincipalID != "":
+		return fmt.Sprintf("group principal \"%s\"", b.GroupPrincipalID)
+	case b.UserPrincipalID != "":
+		return fmt.Sprintf("user principal \"%s\"", b.UserPrincipalID)
+	default:
+		return fmt.Sprintf("user \"%s\"", b.UserID)
+	}
+}
+
+func resourceProjectRoleTemplateBindingCreate(d *schema.ResourceData, m interface{}) error {
+	rancher := m.(Config).Rancher()
+
+	binding := &client.ProjectRoleTemplateBinding{
+		ProjectID:        d.Get("project_id").(string),
+		RoleTemplateID:   d.Get("role_template_id").(string),
+		UserID:           d.Get("user_id").(string),
+		UserPrincipalID:  d.Get("user_principal_id").(string),
+		GroupPrincipalID: d.Get("group_principal_id").(string),
+	}
+	if binding.UserID == "" && binding.UserPrincipalID == "" && binding.GroupPrincipalID == "" {
+		return fmt.Errorf("one of user_id, user_principal_id or group_principal_id has to be specified")
+	}
+
+	if existing, err := projectRoleTemplateBindingBySubject(rancher, binding); err != nil {
+		return err
+	} else if existing != nil {
+		return fmt.Errorf(
+			"role template \"%s\" is already bound to %s in project \"%s\" (ID: \"%s\"), "+
+				"consider importing the existing binding instead",
+			binding.RoleTemplateID, projectRoleTemplateBindingSubject(binding), binding.ProjectID, existing.ID,
+		)
+	}
+
+	newBinding, err := rancher.ProjectRoleTemplateBinding.Create(binding)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(newBinding.ID)
+	d.Set("name", newBinding.Name)
+	d.Set("uuid", newBinding.UUID)
+
+	return nil
+}
+
+func resourceProjectRoleTemplateBindingRead(d *schema.ResourceData, m interface{}) error {
+	rancher := m.(Config).Rancher()
+
+	binding, err := rancher.ProjectRoleTemplateBinding.ByID(d.Id())
+
+	if err != nil {
+		return err
+	} else if binding == nil {
+		// If the binding DOES NOT EXIST, it has probably already been deleted. Time to update the state...
+		d.SetId("")
+		return nil
+	}
+	d.Set("project_id", binding.ProjectID)
+	d.Set("role_template_id", binding.RoleTemplateID)
+	d.Set("user_id", binding.UserID)
+	d.Set("user_principal_id", binding.UserPrincipalID)
+	d.Set("group_principal_id", binding.GroupPrincipalID)
+	d.Set("name", binding.Name)
+	d.Set("uuid", binding.UUID)
+	return nil
+}
+
+func resourceProjectRoleTemplateBindingDelete(d *schema.ResourceData, m interface{}) error {
+	rancher := m.(Config).Rancher()
+
+	id := d.Id()
+	binding, err := rancher.ProjectRoleTemplateBinding.ByID(id)
+	if err != nil {
+		return err
+	}
+	if binding == nil {
+		// If the binding DOES NOT EXIST, it has probably already been deleted. Nothing to do for us here...
+		return nil
+	}
+	return rancher.ProjectRoleTemplateBinding.Delete(binding)
+}
+
+func resourceProjectRoleTemplateBindingExists(d *schema.ResourceData, m interface{}) (bool, error) {
+	rancher := m.(Config).Rancher()
+
+	binding, err := rancher.ProjectRoleTemplateBinding.ByID(d.Id())
+	if err != nil {
+		if _, isAPIError := err.(*clientbase.APIError); isAPIError && err.(*clientbase.APIError).StatusCode == 404 {
+			// Ignore 404 errors...
+			return false, nil
+		}
+	}
+	return binding != nil, nil
+}
+
+func resourceProjectRoleTemplateBindingState(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if err := resourceProjectRoleTemplateBindingRead(d, m); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceProjectRoleTemplateBinding() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceProjectRoleTemplateBindingCreate,
+		Read:   resourceProjectRoleTemplateBindingRead,
+		Delete: resourceProjectRoleTemplateBindingDelete,
+		Exists: resourceProjectRoleTemplateBindingExists,
+		Importer: &schema.ResourceImporter{
+			State: resourceProjectRoleTemplateBindingState,
+		},
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Description: "ID of the project the role template shall be bound in",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"role_template_id": {
+				Description: "ID of the role template to bind (e.g. \"project-member\" or \"read-only\")",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"user_id": {
+				Description:   "ID of the Rancher user the role template shall be bound to",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: conflictingAttributes(projectRoleTemplateBindingSubjects, "user_id"),
+			},
+			"user_principal_id": {
+				Description:   "ID of the user principal (e.g. \"activedirectory_user://CN=...\") the role template shall be bound to",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: conflictingAttributes(projectRoleTemplateBindingSubjects, "user_principal_id"),
+			},
+			"group_principal_id": {
+				Description:   "ID of the group principal (e.g. \"activedirectory_group://CN=...\") the role template shall be bound to",
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: conflictingAttributes(projectRoleTemplateBindingSubjects, "group_principal_id"),
+			},
+			"name": {
+				Description: "Name of the binding as generated by Rancher",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"uuid": {
+				Description: "UUID of the binding as reported by the Rancher API",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
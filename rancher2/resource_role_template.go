@@ -0,0 +1,392 @@
+package rancher2
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/rancher/norman/clientbase"
+	"github.com/rancher/norman/types"
+	"github.com/rancher/types/client/management/v3"
+)
+
+const (
+	roleTemplateContextCluster = "cluster"
+	roleTemplateContextProject = "project"
+)
+
+// roleTemplateByName returns the role template with the given name (and context, if given) if it exists.
+// Names are only unique per context, so it is an error if the name matches more than one role template.
+func roleTemplateByName(c *client.Client, name string, context string) (*client.RoleTemplate, error) {
+	filters := map[string]interface{}{
+		"name": name,
+	}
+	if context != "" {
+		filters["context"] = context
+	}
+	roleTemplates, err := c.RoleTemplate.List(&types.ListOpts{
+		Filters: filters,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if cnt := len(roleTemplates.Data); cnt > 1 {
+		return nil, fmt.Errorf("more than one role template with specified name (\"%s\") found: %d", name, cnt)
+	} else if cnt == 1 {
+		return &roleTemplates.Data[0], nil
+	}
+	return nil, nil
+}
+
+// policyRuleSchema returns the schema of Kubernetes RBAC policy rules as used by role templates and global roles.
+func policyRuleSchema() *schema.Schema {
+	stringSet := func(description string) *schema.Schema {
+		return &schema.Schema{
+			Description: description,
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		}
+	}
+	return &schema.Schema{
+		Description: "Kubernetes RBAC policy rules granted by the role",
+		Type:        schema.TypeList,
+		Optional:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"api_groups":        stringSet("API groups of the resources the rule applies to"),
+				"resources":         stringSet("Resources the rule applies to"),
+				"verbs":             stringSet("Verbs the rule allows (e.g. \"get\", \"list\" or \"*\")"),
+				"resource_names":    stringSet("Names of the resources the rule is restricted to"),
+				"non_resource_urls": stringSet("Non-resource URLs (e.g. \"/healthz\") the rule applies to"),
+			},
+		},
+	}
+}
+
+func expandStringSet(v interface{}) []string {
+	list := v.(*schema.Set).List()
+	values := make([]string, 0, len(list))
+	for _, value := range list {
+		values = append(values, value.(string))
+	}
+	return values
+}
+
+func expandStringList(v interface{}) []string {
+	list := v.([]interface{})
+	values := make([]string, 0, len(list))
+	for _, value := range list {
+		values = append(values, value.(string))
+	}
+	return values
+}
+
+func expandStringMap(v interface{}) map[string]string {
+	m := v.(map[string]interface{})
+	values := make(map[string]string, len(m))
+	for k, value := range m {
+		values[k] = value.(string)
+	}
+	return values
+}
+
+func flattenStringSet(values []string) *schema.Set {
+	list := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		list = append(list, value)
+	}
+	return schema.NewSet(schema.HashString, list)
+}
+
+// expandPolicyRules converts the configured policy rules to their API representation.
+func expandPolicyRules(v interface{}) []client.PolicyRule {
+	list := v.([]interface{})
+	rules := make([]client.PolicyRule, 0, len(list))
+	for _, r := range list {
+		rule := r.(map[string]interface{})
+		rules = append(rules, client.PolicyRule{
+			APIGroups:       expandStringSet(rule["api_groups"]),
+			Resources:       expandStringSet(rule["resources"]),
+			Verbs:           expandStringSet(rule["verbs"]),
+			ResourceNames:   expandStringSet(rule["resource_names"]),
+			NonResourceURLs: expandStringSet(rule["non_resource_urls"]),
+		})
+	}
+	return rules
+}
+
+// flattenPolicyRules converts policy rules as returned by the API to their terraform representation.
+func flattenPolicyRules(rules []client.PolicyRule) []interface{} {
+	list := make([]interface{}, 0, len(rules))
+	for _, rule := range rules {
+		list = append(list, map[string]interface{}{
+			"api_groups":        flattenStringSet(rule.APIGroups),
+			"resources":         flattenStringSet(rule.Resources),
+			"verbs":             flattenStringSet(rule.Verbs),
+			"resource_names":    flattenStringSet(rule.ResourceNames),
+			"non_resource_urls": flattenStringSet(rule.NonResourceURLs),
+		})
+	}
+	return list
+}
+
+// roleTemplateDefaults returns the configured creator default flags of a role template. The "default_role"
+// flag is a shortcut for the creator default flag that matches the context of the role template.
+func roleTemplateDefaults(d *schema.ResourceData) (clusterCreatorDefault bool, projectCreatorDefault bool) {
+	clusterCreatorDefault = d.Get("cluster_creator_default").(bool)
+	projectCreatorDefault = d.Get("project_creator_default").(bool)
+	if d.Get("default_role").(bool) {
+		switch d.Get("context").(string) {
+		case roleTemplateContextCluster:
+			clusterCreatorDefault = true
+		case roleTemplateContextProject:
+			projectCreatorDefault = true
+		}
+	}
+	return clusterCreatorDefault, projectCreatorDefault
+}
+
+// setRoleTemplateDefaults updates the terraform state with the creator default flags of the given role
+// template. Rancher only knows the creator default flags, so the one matching the context of the role template
+// is reported as "default_role" if that has been configured. Otherwise we'd report a difference forever.
+func setRoleTemplateDefaults(d *schema.ResourceData, roleTemplate *client.RoleTemplate) {
+	contextKey, otherKey := "cluster_creator_default", "project_creator_default"
+	contextDefault, otherDefault := roleTemplate.ClusterCreatorDefault, roleTemplate.ProjectCreatorDefault
+	if roleTemplate.Context == roleTemplateContextProject {
+		contextKey, otherKey = otherKey, contextKey
+		contextDefault, otherDefault = otherDefault, contextDefault
+	}
+	if !contextDefault || !d.Get("default_role").(bool) {
+		d.Set("default_role", false)
+		d.Set(contextKey, contextDefault)
+	}
+	d.Set(otherKey, otherDefault)
+}
+
+// setRoleTemplate updates the terraform state with the given role template.
+func setRoleTemplate(d *schema.ResourceData, roleTemplate *client.RoleTemplate) error {
+	d.Set("name", roleTemplate.Name)
+	d.Set("description", roleTemplate.Description)
+	d.Set("context", roleTemplate.Context)
+	d.Set("locked", roleTemplate.Locked)
+	d.Set("builtin", roleTemplate.Builtin)
+	d.Set("hidden", roleTemplate.Hidden)
+	d.Set("external", roleTemplate.External)
+	d.Set("uuid", roleTemplate.UUID)
+	if err := d.Set("role_template_ids", roleTemplate.RoleTemplateIDs); err != nil {
+		return err
+	}
+	return d.Set("rules", flattenPolicyRules(roleTemplate.Rules))
+}
+
+func resourceRoleTemplateCreate(d *schema.ResourceData, m interface{}) error {
+	rancher := m.(Config).Rancher()
+
+	name := d.Get("name").(string)
+	context := d.Get("context").(string)
+
+	if roleTemplate, err := roleTemplateByName(rancher, name, context); err != nil {
+		return err
+	} else if roleTemplate != nil {
+		return fmt.Errorf("role template with name \"%s\" (ID: \"%s\") does already exist", name, roleTemplate.ID)
+	}
+
+	clusterCreatorDefault, projectCreatorDefault := roleTemplateDefaults(d)
+	roleTemplate, err := rancher.RoleTemplate.Create(&client.RoleTemplate{
+		Name:                  name,
+		Description:           d.Get("description").(string),
+		Context:               context,
+		Locked:                d.Get("locked").(bool),
+		ClusterCreatorDefault: clusterCreatorDefault,
+		ProjectCreatorDefault: projectCreatorDefault,
+		RoleTemplateIDs:       expandStringSet(d.Get("role_template_ids")),
+		Rules:                 expandPolicyRules(d.Get("rules")),
+	})
+	if err != nil {
+		return err
+	}
+
+	d.SetId(roleTemplate.ID)
+	setRoleTemplateDefaults(d, roleTemplate)
+	return setRoleTemplate(d, roleTemplate)
+}
+
+func resourceRoleTemplateRead(d *schema.ResourceData, m interface{}) error {
+	rancher := m.(Config).Rancher()
+
+	roleTemplate, err := rancher.RoleTemplate.ByID(d.Id())
+
+	if err != nil {
+		return err
+	} else if roleTemplate == nil {
+		// If the role template DOES NOT EXIST, it has probably already been deleted. Time to update the state...
+		d.SetId("")
+		return nil
+	}
+	setRoleTemplateDefaults(d, roleTemplate)
+	return setRoleTemplate(d, roleTemplate)
+}
+
+func resourceRoleTemplateUpdate(d *schema.ResourceData, m interface{}) error {
+	rancher := m.(Config).Rancher()
+
+	id := d.Id()
+
+	d.Partial(true)
+
+	roleTemplate, err := rancher.RoleTemplate.ByID(id)
+	if err != nil {
+		return err
+	}
+	if roleTemplate == nil {
+		return fmt.Errorf("role template with ID \"%s\" could not be found", id)
+	}
+
+	updates := map[string]interface{}{}
+	if d.HasChange("name") {
+		updates["name"] = d.Get("name").(string)
+	}
+	if d.HasChange("description") {
+		updates["description"] = d.Get("description").(string)
+	}
+	if d.HasChange("locked") {
+		updates["locked"] = d.Get("locked").(bool)
+	}
+	if d.HasChange("cluster_creator_default") || d.HasChange("project_creator_default") || d.HasChange("default_role") {
+		clusterCreatorDefault, projectCreatorDefault := roleTemplateDefaults(d)
+		updates["clusterCreatorDefault"] = clusterCreatorDefault
+		updates["projectCreatorDefault"] = projectCreatorDefault
+	}
+	if d.HasChange("role_template_ids") {
+		updates["roleTemplateIds"] = expandStringSet(d.Get("role_template_ids"))
+	}
+	if d.HasChange("rules") {
+		updates["rules"] = expandPolicyRules(d.Get("rules"))
+	}
+
+	if _, err = rancher.RoleTemplate.Update(roleTemplate, updates); err != nil {
+		return err
+	}
+
+	d.Partial(false)
+
+	return resourceRoleTemplateRead(d, m)
+}
+
+func resourceRoleTemplateDelete(d *schema.ResourceData, m interface{}) error {
+	rancher := m.(Config).Rancher()
+
+	id := d.Id()
+	roleTemplate, err := rancher.RoleTemplate.ByID(id)
+	if err != nil {
+		return err
+	}
+	if roleTemplate == nil {
+		// If the role template DOES NOT EXIST, it has probably already been deleted. Nothing to do for us here...
+		return nil
+	}
+	if roleTemplate.Builtin {
+		return fmt.Errorf("role template \"%s\" is built into Rancher and cannot be deleted", roleTemplate.Name)
+	}
+	return rancher.RoleTemplate.Delete(roleTemplate)
+}
+
+func resourceRoleTemplateExists(d *schema.ResourceData, m interface{}) (bool, error) {
+	rancher := m.(Config).Rancher()
+
+	roleTemplate, err := rancher.RoleTemplate.ByID(d.Id())
+	if err != nil {
+		if _, isAPIError := err.(*clientbase.APIError); isAPIError && err.(*clientbase.APIError).StatusCode == 404 {
+			// Ignore 404 errors...
+			return false, nil
+		}
+	}
+	return roleTemplate != nil, nil
+}
+
+func resourceRoleTemplateState(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if err := resourceRoleTemplateRead(d, m); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceRoleTemplate() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceRoleTemplateCreate,
+		Read:   resourceRoleTemplateRead,
+		Update: resourceRoleTemplateUpdate,
+		Delete: resourceRoleTemplateDelete,
+		Exists: resourceRoleTemplateExists,
+		Importer: &schema.ResourceImporter{
+			State: resourceRoleTemplateState,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "Name of the role template",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"description": {
+				Description: "Description of the role template",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"context": {
+				Description:  "Context the role template can be bound in (\"cluster\" or \"project\")",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{roleTemplateContextCluster, roleTemplateContextProject}, false),
+			},
+			"rules": policyRuleSchema(),
+			"role_template_ids": {
+				Description: "IDs of the role templates whose rules shall be inherited",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"locked": {
+				Description: "Whether the role template may no longer be used for new bindings",
+				Type:        schema.TypeBool,
+				Optional:    true,
+			},
+			"default_role": {
+				Description: "Whether the role template is granted to creators of clusters or projects (depending on its context)",
+				Type:        schema.TypeBool,
+				Optional:    true,
+			},
+			"cluster_creator_default": {
+				Description: "Whether the role template is granted to creators of clusters",
+				Type:        schema.TypeBool,
+				Optional:    true,
+			},
+			"project_creator_default": {
+				Description: "Whether the role template is granted to creators of projects",
+				Type:        schema.TypeBool,
+				Optional:    true,
+			},
+			"builtin": {
+				Description: "Whether the role template is built into Rancher",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"hidden": {
+				Description: "Whether the role template is hidden in the UI",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"external": {
+				Description: "Whether the rules of the role template are managed outside of Rancher",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"uuid": {
+				Description: "UUID of the role template as reported by the Rancher API",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
@@ -0,0 +1,52 @@
+package rancher2
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/rancher/types/client/management/v3"
+)
+
+func TestSetRoleTemplateDefaults(t *testing.T) {
+	tt := []struct {
+		raw          map[string]interface{}
+		roleTemplate client.RoleTemplate
+		expected     map[string]bool
+	}{
+		{
+			raw:          map[string]interface{}{"context": "cluster", "default_role": true},
+			roleTemplate: client.RoleTemplate{Context: "cluster", ClusterCreatorDefault: true},
+			expected:     map[string]bool{"default_role": true, "cluster_creator_default": false, "project_creator_default": false},
+		},
+		{
+			raw:          map[string]interface{}{"context": "cluster", "cluster_creator_default": true},
+			roleTemplate: client.RoleTemplate{Context: "cluster", ClusterCreatorDefault: true},
+			expected:     map[string]bool{"default_role": false, "cluster_creator_default": true, "project_creator_default": false},
+		},
+		{
+			raw:          map[string]interface{}{"context": "project", "default_role": true, "project_creator_default": true},
+			roleTemplate: client.RoleTemplate{Context: "project", ProjectCreatorDefault: true},
+			expected:     map[string]bool{"default_role": true, "cluster_creator_default": false, "project_creator_default": true},
+		},
+		{
+			raw:          map[string]interface{}{"context": "project", "default_role": true},
+			roleTemplate: client.RoleTemplate{Context: "project", ClusterCreatorDefault: true},
+			expected:     map[string]bool{"default_role": false, "cluster_creator_default": true, "project_creator_default": false},
+		},
+		{
+			// Imported role templates
+			raw:          map[string]interface{}{"context": "project"},
+			roleTemplate: client.RoleTemplate{Context: "project", ProjectCreatorDefault: true},
+			expected:     map[string]bool{"default_role": false, "cluster_creator_default": false, "project_creator_default": true},
+		},
+	}
+	for _, td := range tt {
+		d := schema.TestResourceDataRaw(t, resourceRoleTemplate().Schema, td.raw)
+		setRoleTemplateDefaults(d, &td.roleTemplate)
+		for k, v := range td.expected {
+			if actual := d.Get(k).(bool); actual != v {
+				t.Errorf("unexpected %s for %v and %+v: expected %v, got %v", k, td.raw, td.roleTemplate, v, actual)
+			}
+		}
+	}
+}
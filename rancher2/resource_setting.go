@@ -0,0 +1,194 @@
+package rancher2
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/rancher/norman/clientbase"
+	"github.com/rancher/types/client/management/v3"
+)
+
+// settingReadOnly checks whether the given setting is read-only. Rancher does not offer a link for updating
+// read-only settings such as "server-version".
+func settingReadOnly(s *client.Setting) bool {
+	_, updatable := s.Links["update"]
+	return !updatable
+}
+
+// setSetting updates the terraform state with the given setting.
+func setSetting(d *schema.ResourceData, s *client.Setting) {
+	d.Set("name", s.Name)
+	d.Set("value", s.Value)
+	d.Set("default", s.Default)
+	d.Set("customized", s.Customized)
+	d.Set("uuid", s.UUID)
+}
+
+func resourceSettingCreate(d *schema.ResourceData, m interface{}) error {
+	rancher := m.(Config).Rancher()
+
+	name := d.Get("name").(string)
+
+	// Settings known to Rancher already exist with their default values, so we only create unknown ones and
+	// update the values of all others.
+	setting, err := rancher.Setting.ByID(name)
+	if apiError, isAPIError := err.(*clientbase.APIError); isAPIError && apiError.StatusCode == 404 {
+		created, err := rancher.Setting.Create(&client.Setting{
+			Name:  name,
+			Value: d.Get("value").(string),
+		})
+		if err != nil {
+			return err
+		}
+		d.SetId(created.ID)
+		d.Set("created", true)
+		return resourceSettingRead(d, m)
+	} else if err != nil {
+		return err
+	}
+	if settingReadOnly(setting) {
+		return fmt.Errorf("setting \"%s\" is read-only and cannot be managed", name)
+	}
+	if _, err := rancher.Setting.Update(setting, map[string]interface{}{
+		"value": d.Get("value").(string),
+	}); err != nil {
+		return err
+	}
+
+	d.SetId(setting.ID)
+	d.Set("created", false)
+	return resourceSettingRead(d, m)
+}
+
+func resourceSettingRead(d *schema.ResourceData, m interface{}) error {
+	rancher := m.(Config).Rancher()
+
+	setting, err := rancher.Setting.ByID(d.Id())
+
+	if err != nil {
+		return err
+	} else if setting == nil {
+		// If the setting DOES NOT EXIST, it has probably already been deleted. Time to update the state...
+		d.SetId("")
+		return nil
+	}
+	setSetting(d, setting)
+	return nil
+}
+
+func resourceSettingUpdate(d *schema.ResourceData, m interface{}) error {
+	rancher := m.(Config).Rancher()
+
+	id := d.Id()
+	setting, err := rancher.Setting.ByID(id)
+	if err != nil {
+		return err
+	}
+	if setting == nil {
+		return fmt.Errorf("setting \"%s\" could not be found", id)
+	}
+
+	if d.HasChange("value") {
+		if _, err := rancher.Setting.Update(setting, map[string]interface{}{
+			"value": d.Get("value").(string),
+		}); err != nil {
+			return err
+		}
+	}
+	return resourceSettingRead(d, m)
+}
+
+func resourceSettingDelete(d *schema.ResourceData, m interface{}) error {
+	rancher := m.(Config).Rancher()
+
+	id := d.Id()
+	setting, err := rancher.Setting.ByID(id)
+	if err != nil {
+		return err
+	}
+	if setting == nil {
+		// If the setting DOES NOT EXIST, it has probably already been deleted. Nothing to do for us here...
+		return nil
+	}
+	if d.Get("created").(bool) {
+		return rancher.Setting.Delete(setting)
+	}
+	// Settings built into Rancher cannot be deleted. Without a value they fall back to their default, so
+	// clearing the value restores it.
+	_, err = rancher.Setting.Update(setting, map[string]interface{}{
+		"value": "",
+	})
+	return err
+}
+
+func resourceSettingExists(d *schema.ResourceData, m interface{}) (bool, error) {
+	rancher := m.(Config).Rancher()
+
+	setting, err := rancher.Setting.ByID(d.Id())
+	if err != nil {
+		if _, isAPIError := err.(*clientbase.APIError); isAPIError && err.(*clientbase.APIError).StatusCode == 404 {
+			// Ignore 404 errors...
+			return false, nil
+		}
+	}
+	return setting != nil, nil
+}
+
+func resourceSettingState(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if err := resourceSettingRead(d, m); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// settingAttributesSchema returns the schema of the attributes Rancher reports about settings.
+func settingAttributesSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"default": {
+			Description: "Default value of the setting",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"customized": {
+			Description: "Whether the value of the setting differs from its default",
+			Type:        schema.TypeBool,
+			Computed:    true,
+		},
+		"uuid": {
+			Description: "UUID of the setting as reported by the Rancher API",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+	}
+}
+
+func resourceSetting() *schema.Resource {
+	s := settingAttributesSchema()
+	s["name"] = &schema.Schema{
+		Description: "Name of the setting (e.g. \"server-url\" or \"telemetry-opt\")",
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+	}
+	s["value"] = &schema.Schema{
+		Description: "Value of the setting",
+		Type:        schema.TypeString,
+		Required:    true,
+	}
+	s["created"] = &schema.Schema{
+		Description: "Whether the setting has been created by Terraform, in which case it is deleted on destroy instead of being reset to its default",
+		Type:        schema.TypeBool,
+		Computed:    true,
+	}
+	return &schema.Resource{
+		Create: resourceSettingCreate,
+		Read:   resourceSettingRead,
+		Update: resourceSettingUpdate,
+		Delete: resourceSettingDelete,
+		Exists: resourceSettingExists,
+		Importer: &schema.ResourceImporter{
+			State: resourceSettingState,
+		},
+		Schema: s,
+	}
+}
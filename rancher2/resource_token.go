@@ -1,39 +1,191 @@
 package rancher2
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/rancher/norman/clientbase"
+	"github.com/rancher/norman/types"
 	"github.com/rancher/types/client/management/v3"
+	"io/ioutil"
 	"strings"
+	"time"
 )
 
-func resourceTokenCreate(d *schema.ResourceData, m interface{}) error {
-	rancher := m.(Config).Rancher()
+// token extends the generated API type by the attributes of tokens that are not known to the version of the
+// Rancher client library we are using: the cluster tokens can be scoped to, whether they are enabled and
+// when they have been used for the last time.
+type token struct {
+	client.Token
+	ClusterID  string `json:"clusterId,omitempty" yaml:"clusterId,omitempty"`
+	Enabled    *bool  `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+	LastUsedAt string `json:"lastUsedAt,omitempty" yaml:"lastUsedAt,omitempty"`
+}
+
+// tokenCollection is the collection of the extended token type.
+type tokenCollection struct {
+	types.Collection
+	Data []token `json:"data,omitempty"`
+}
+
+// tokenByID returns the token with the given ID if it exists.
+func tokenByID(c *client.Client, id string) (*token, error) {
+	t := &token{}
+	if err := c.ByID(client.TokenType, id, t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
 
-	token, err := rancher.Token.Create(&client.Token{
-		Description: d.Get("description").(string),
-		UserID:      d.Get("user_id").(string),
-	})
+// parseTokenKey splits an API token into its access key and secret key. The token itself is never part of
+// errors, as they might end up in logs.
+func parseTokenKey(t string) (accessKey string, secretKey string, err error) {
+	t = strings.TrimPrefix(t, "Bearer ")
+	idx := strings.Index(t, ":")
+	if idx <= 0 || idx == len(t)-1 {
+		return "", "", fmt.Errorf("malformed API token: expected \"<access key>:<secret key>\"")
+	}
+	return t[:idx], t[idx+1:], nil
+}
+
+// hashSecret returns the hex-encoded SHA-256 hash of the given secret.
+func hashSecret(secret string) string {
+	hash := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(hash[:])
+}
+
+// setTokenSecrets updates the terraform state with the secrets of a newly created token. Depending on the
+// configuration, the cleartext secrets are only written to a file or encrypted with a PGP public key and
+// never stored in the state.
+func setTokenSecrets(d *schema.ResourceData, t *token) error {
+	accessKey, secretKey, err := parseTokenKey(t.Token.Token)
+	if err != nil {
+		return fmt.Errorf("unable to read secret of token \"%s\": %v", t.ID, err)
+	}
+	d.Set("access_key", accessKey)
+	d.Set("secret_key_hash", hashSecret(secretKey))
+	if d.Get("store_secret_hash").(bool) {
+		d.Set("token", "")
+		d.Set("secret_key", "")
+	} else {
+		d.Set("token", t.Token.Token)
+		d.Set("secret_key", secretKey)
+	}
+
+	if pgpKey := d.Get("pgp_key").(string); pgpKey != "" {
+		fingerprint, encrypted, err := encryptWithPGPKey(pgpKey, t.Token.Token)
+		if err != nil {
+			return fmt.Errorf("unable to encrypt secret of token \"%s\": %v", t.ID, err)
+		}
+		d.Set("key_fingerprint", fingerprint)
+		d.Set("encrypted_token", encrypted)
+	}
+	if outputFile := d.Get("output_file").(string); outputFile != "" {
+		if err := ioutil.WriteFile(outputFile, []byte(t.Token.Token), 0600); err != nil {
+			return fmt.Errorf("unable to write secret of token \"%s\" to %s: %v", t.ID, outputFile, err)
+		}
+	}
+	return nil
+}
+
+// tokenEnabled returns whether the given token is enabled. Tokens that do not say otherwise are enabled.
+func tokenEnabled(t *token) bool {
+	return t.Enabled == nil || *t.Enabled
+}
+
+// parseTokenTTL parses the given TTL (e.g. "720h"). An empty TTL means that the token never expires.
+func parseTokenTTL(ttl string) (time.Duration, error) {
+	if ttl == "" {
+		return 0, nil
+	}
+	duration, err := time.ParseDuration(ttl)
+	if err != nil {
+		return 0, fmt.Errorf("invalid TTL \"%s\": %v", ttl, err)
+	}
+	if duration < 0 {
+		return 0, fmt.Errorf("invalid TTL \"%s\": must not be negative", ttl)
+	}
+	return duration, nil
+}
+
+// formatTokenTTL converts the TTL of a token as returned by the API into a duration string.
+func formatTokenTTL(ttlMillis int64) string {
+	if ttlMillis <= 0 {
+		return ""
+	}
+	return (time.Duration(ttlMillis) * time.Millisecond).String()
+}
+
+func validateTokenTTL(v interface{}, k string) (ws []string, errs []error) {
+	if _, err := parseTokenTTL(v.(string)); err != nil {
+		errs = append(errs, fmt.Errorf("%s: %v", k, err))
+	}
+	return
+}
+
+// tokenReadyForRenewal checks whether a token expires within the given period of time (or has expired already).
+func tokenReadyForRenewal(expiresAt string, expired bool, renewBefore time.Duration, now time.Time) (bool, error) {
+	if expired {
+		return true, nil
+	}
+	if expiresAt == "" || renewBefore <= 0 {
+		return false, nil
+	}
+	expiry, err := time.Parse(time.RFC3339, expiresAt)
+	if err != nil {
+		return false, fmt.Errorf("invalid expiry date \"%s\": %v", expiresAt, err)
+	}
+	return expiry.Sub(now) < renewBefore, nil
+}
+
+func setToken(d *schema.ResourceData, t *token) {
+	d.Set("user_id", t.UserID)
+	d.Set("description", t.Description)
+	d.Set("ttl", formatTokenTTL(t.TTLMillis))
+	d.Set("cluster_id", t.ClusterID)
+	d.Set("enabled", tokenEnabled(t))
+	d.Set("expires_at", t.ExpiresAt)
+	d.Set("expired", t.Expired)
+}
+
+// createToken creates a new token as configured and updates the terraform state accordingly.
+func createToken(d *schema.ResourceData, c *client.Client) error {
+	ttl, err := parseTokenTTL(d.Get("ttl").(string))
 	if err != nil {
 		return err
 	}
+	enabled := d.Get("enabled").(bool)
 
-	d.SetId(token.ID)
-	d.Set("user_id", token.UserID)
-	d.Set("description", token.Description)
-	d.Set("token", token.Token)
+	newToken := &token{}
+	err = c.Create(client.TokenType, &token{
+		Token: client.Token{
+			Description: d.Get("description").(string),
+			UserID:      d.Get("user_id").(string),
+			TTLMillis:   int64(ttl / time.Millisecond),
+		},
+		ClusterID: d.Get("cluster_id").(string),
+		Enabled:   &enabled,
+	}, newToken)
+	if err != nil {
+		return err
+	}
 
-	key := strings.Split(token.Token, ":")
-	d.Set("access_key", key[0])
-	d.Set("secret_key", key[1])
+	d.SetId(newToken.ID)
+	setToken(d, newToken)
+	d.Set("ready_for_renewal", false)
 
-	return nil
+	return setTokenSecrets(d, newToken)
+}
+
+func resourceTokenCreate(d *schema.ResourceData, m interface{}) error {
+	return createToken(d, m.(Config).Rancher())
 }
 
 func resourceTokenRead(d *schema.ResourceData, m interface{}) error {
 	rancher := m.(Config).Rancher()
 
-	token, err := rancher.Token.ByID(d.Id())
+	token, err := tokenByID(rancher, d.Id())
 
 	if err != nil {
 		return err
@@ -42,15 +194,104 @@ func resourceTokenRead(d *schema.ResourceData, m interface{}) error {
 		d.SetId("")
 		return nil
 	}
-	d.Set("user_id", token.UserID)
-	d.Set("description", token.Description)
+	setToken(d, token)
+
+	renewBefore, err := parseTokenTTL(d.Get("renew_before").(string))
+	if err != nil {
+		return err
+	}
+	readyForRenewal, err := tokenReadyForRenewal(token.ExpiresAt, token.Expired, renewBefore, time.Now())
+	if err != nil {
+		return err
+	}
+	d.Set("ready_for_renewal", readyForRenewal)
 	return nil
 }
 
-func resourceTokenUpdate(d *schema.ResourceData, m interface{}) error {
-	// TODO Implement me?
+// resourceTokenCustomizeDiff plans the rotation of tokens that are about to expire or whose rotation has been
+// triggered explicitly. It also makes sure that the secret of tokens whose secret is only stored as a hash is
+// emitted at least once, as it could never be recovered otherwise.
+func resourceTokenCustomizeDiff(d *schema.ResourceDiff, m interface{}) error {
+	if d.Get("store_secret_hash").(bool) && d.NewValueKnown("output_file") && d.NewValueKnown("pgp_key") &&
+		d.Get("output_file").(string) == "" && d.Get("pgp_key").(string) == "" {
+		return fmt.Errorf("store_secret_hash requires output_file or pgp_key, otherwise the token would be lost")
+	}
+	if d.Id() == "" {
+		return nil
+	}
+	if !d.HasChange("rotate_when_changed") && !d.Get("ready_for_renewal").(bool) {
+		return nil
+	}
+	if err := d.SetNew("ready_for_renewal", false); err != nil {
+		return err
+	}
+	for _, key := range []string{"token", "access_key", "secret_key", "secret_key_hash", "encrypted_token", "expires_at", "expired"} {
+		if err := d.SetNewComputed(key); err != nil {
+			return err
+		}
+	}
 	return nil
+}
+
+// rotateToken replaces the current token by a new one. The new token is created before the old one is
+// deleted, so that consumers never see a gap.
+func rotateToken(d *schema.ResourceData, c *client.Client) error {
+	oldID := d.Id()
+	if err := createToken(d, c); err != nil {
+		return fmt.Errorf("unable to create token to replace token \"%s\": %v", oldID, err)
+	}
+	oldToken, err := c.Token.ByID(oldID)
+	if err != nil {
+		if _, isAPIError := err.(*clientbase.APIError); isAPIError && err.(*clientbase.APIError).StatusCode == 404 {
+			// The old token has already been deleted, e.g. because it has expired...
+			return nil
+		}
+		return err
+	}
+	return c.Token.Delete(oldToken)
+}
+
+func resourceTokenUpdate(d *schema.ResourceData, m interface{}) error {
+	rancher := m.(Config).Rancher()
+
+	if d.HasChange("rotate_when_changed") || d.HasChange("ready_for_renewal") {
+		// The new token is created with all mutable attributes as configured, so there is nothing left to update.
+		if err := rotateToken(d, rancher); err != nil {
+			return err
+		}
+		return resourceTokenRead(d, m)
+	}
+
+	id := d.Id()
+
+	d.Partial(true)
+
+	token, err := tokenByID(rancher, id)
+	if err != nil {
+		return err
+	}
+	if token == nil {
+		return fmt.Errorf("token with ID \"%s\" could not be found", id)
+	}
+
+	// All other attributes of tokens are immutable and force new tokens to be created.
+	updates := map[string]interface{}{}
+	if d.HasChange("description") {
+		updates["description"] = d.Get("description").(string)
+	}
+	if d.HasChange("enabled") {
+		updates["enabled"] = d.Get("enabled").(bool)
+	}
+
+	if len(updates) > 0 {
+		if _, err = rancher.Token.Update(&token.Token, updates); err != nil {
+			return err
+		}
+	}
 
+	d.Partial(false)
+
+	return resourceTokenRead(d, m)
 }
 
 func resourceTokenDelete(d *schema.ResourceData, m interface{}) error {
@@ -98,21 +339,93 @@ func resourceToken() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceTokenState,
 		},
+		CustomizeDiff: resourceTokenCustomizeDiff,
 		Schema: map[string]*schema.Schema{
 			"user_id": {
 				Description: "ID of the user for whom to create the token",
 				Type:        schema.TypeString,
 				Required:    true,
+				ForceNew:    true,
 			},
 			"description": {
 				Description: "Description of the token",
 				Type:        schema.TypeString,
 				Optional:    true,
 			},
+			"ttl": {
+				Description:  "Time to live of the token (e.g. \"720h\"), the token never expires if not given",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateTokenTTL,
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					// Rancher reports TTLs in milliseconds, so "720h" and "720h0m0s" are the same.
+					oldTTL, oldErr := parseTokenTTL(old)
+					newTTL, newErr := parseTokenTTL(new)
+					return oldErr == nil && newErr == nil && oldTTL == newTTL
+				},
+			},
+			"cluster_id": {
+				Description: "ID of the cluster the token shall be scoped to (e.g. for kubeconfig files)",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"enabled": {
+				Description: "Whether the token may be used to authenticate",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"rotate_when_changed": {
+				Description: "Arbitrary map of values that, when changed, will trigger the rotation of the token",
+				Type:        schema.TypeMap,
+				Optional:    true,
+			},
+			"renew_before": {
+				Description:  "Period of time before its expiry (e.g. \"168h\") in which the token shall be rotated",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateTokenTTL,
+			},
+			"ready_for_renewal": {
+				Description: "Whether the token will be rotated on the next apply",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"expires_at": {
+				Description: "Expiry date of the token (empty if the token never expires)",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"expired": {
+				Description: "Whether the token has expired",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"store_secret_hash": {
+				Description: "Whether to store only a hash of the secret key in the state instead of the token itself (requires output_file or pgp_key)",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"output_file": {
+				Description: "Path of a file the API token shall be written to once it has been created",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"pgp_key": {
+				Description: "ASCII-armored or base64-encoded PGP public key to encrypt the API token with",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+			},
 			"token": {
-				Description: "API token",
+				Description: "API token (empty if only a hash of the secret key is stored)",
 				Type:        schema.TypeString,
 				Computed:    true,
+				Sensitive:   true,
 			},
 			"access_key": {
 				Description: "Access key",
@@ -120,7 +433,23 @@ func resourceToken() *schema.Resource {
 				Computed:    true,
 			},
 			"secret_key": {
-				Description: "Secret key",
+				Description: "Secret key (empty if only a hash of the secret key is stored)",
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"secret_key_hash": {
+				Description: "Hex-encoded SHA-256 hash of the secret key",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"encrypted_token": {
+				Description: "Base64-encoded API token, encrypted with the given PGP public key",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"key_fingerprint": {
+				Description: "Fingerprint of the PGP public key the API token has been encrypted with",
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
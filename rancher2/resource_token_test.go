@@ -0,0 +1,62 @@
+package rancher2
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTokenReadyForRenewal(t *testing.T) {
+	now := time.Date(2018, 10, 1, 12, 0, 0, 0, time.UTC)
+	tt := []struct {
+		expiresAt   string
+		expired     bool
+		renewBefore time.Duration
+		expected    bool
+		errExpected bool
+	}{
+		{expiresAt: "", renewBefore: 24 * time.Hour, expected: false},
+		{expiresAt: "2018-10-10T12:00:00Z", renewBefore: 0, expected: false},
+		{expiresAt: "2018-10-10T12:00:00Z", renewBefore: 24 * time.Hour, expected: false},
+		{expiresAt: "2018-10-02T11:00:00Z", renewBefore: 24 * time.Hour, expected: true},
+		{expiresAt: "2018-09-30T12:00:00Z", renewBefore: 24 * time.Hour, expected: true},
+		{expiresAt: "2018-10-10T12:00:00Z", expired: true, expected: true},
+		{expiresAt: "next tuesday", renewBefore: 24 * time.Hour, errExpected: true},
+	}
+	for _, td := range tt {
+		ready, err := tokenReadyForRenewal(td.expiresAt, td.expired, td.renewBefore, now)
+		if (td.errExpected && err == nil) || (!td.errExpected && err != nil) {
+			t.Errorf("unexpected error result for \"%s\": %v", td.expiresAt, err)
+			continue
+		}
+		if ready != td.expected {
+			t.Errorf("unexpected result for \"%s\" (renew before %s): %v", td.expiresAt, td.renewBefore, ready)
+		}
+	}
+}
+
+func TestParseTokenKey(t *testing.T) {
+	tt := []struct {
+		token       string
+		accessKey   string
+		secretKey   string
+		errExpected bool
+	}{
+		{token: "token-abcde:s3cr3t", accessKey: "token-abcde", secretKey: "s3cr3t"},
+		{token: "Bearer token-abcde:s3cr3t", accessKey: "token-abcde", secretKey: "s3cr3t"},
+		{token: "token-abcde:s3c:r3t", accessKey: "token-abcde", secretKey: "s3c:r3t"},
+		{token: "", errExpected: true},
+		{token: "s3cr3t", errExpected: true},
+		{token: ":s3cr3t", errExpected: true},
+		{token: "token-abcde:", errExpected: true},
+	}
+	for _, td := range tt {
+		accessKey, secretKey, err := parseTokenKey(td.token)
+		if (td.errExpected && err == nil) || (!td.errExpected && err != nil) {
+			t.Errorf("unexpected error result for \"%s\": %v", td.token, err)
+			continue
+		}
+		if accessKey != td.accessKey || secretKey != td.secretKey {
+			t.Errorf("unexpected keys for \"%s\": \"%s\", \"%s\"", td.token, accessKey, secretKey)
+		}
+	}
+}
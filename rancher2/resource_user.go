@@ -2,9 +2,6 @@ package rancher2
 
 import (
 	"fmt"
-	"math/rand"
-	"strings"
-	"time"
 
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/rancher/norman/clientbase"
@@ -29,23 +26,134 @@ func userByUsername(c *client.Client, username string) (*client.User, error) {
 	return nil, nil
 }
 
-func getActiveDirectoryUser(u *client.User) string {
-	pfx := "activedirectory_user://"
-	pfxLen := len(pfx)
+// userPrincipalIDs returns the external principal IDs configured for a user, either given via the
+// "principal_ids" attribute or via the attributes dedicated to specific authentication providers. As both
+// are computed, we have to merge changes of the dedicated attributes into the known principal IDs.
+func userPrincipalIDs(d *schema.ResourceData) []string {
+	principalIDs := expandStringSet(d.Get("principal_ids"))
+	if d.HasChange("principal_ids") {
+		return principalIDs
+	}
+	for _, principalType := range externalUserPrincipalTypes {
+		if !d.HasChange(principalType) {
+			continue
+		}
+		merged := make([]string, 0, len(principalIDs)+1)
+		for _, id := range principalIDs {
+			if p, err := parsePrincipalID(id); err != nil || p.Type() != principalType {
+				merged = append(merged, id)
+			}
+		}
+		if name := d.Get(principalType).(string); name != "" {
+			merged = append(merged, formatPrincipalID(principalType, name))
+		}
+		principalIDs = merged
+	}
+	return principalIDs
+}
+
+// userPrincipalIDsChanged checks whether any of the external principal IDs of a user have been changed.
+func userPrincipalIDsChanged(d *schema.ResourceData) bool {
+	if d.HasChange("principal_ids") {
+		return true
+	}
+	for _, principalType := range externalUserPrincipalTypes {
+		if d.HasChange(principalType) {
+			return true
+		}
+	}
+	return false
+}
+
+// setUserPrincipalIDs updates the terraform state with the principal IDs of the given user. Local principals
+// are assigned by Rancher itself and are therefore not reported.
+func setUserPrincipalIDs(d *schema.ResourceData, u *client.User) error {
+	principalIDs := make([]string, 0, len(u.PrincipalIDs))
+	names := make(map[string]string, len(externalUserPrincipalTypes))
 	for _, id := range u.PrincipalIDs {
-		idLen := len(id)
-		if strings.Index(id, pfx) == 0 {
-			return id[pfxLen : idLen-pfxLen]
+		p, err := parsePrincipalID(id)
+		if err != nil {
+			return err
+		}
+		if p.Provider == localPrincipalProvider {
+			continue
 		}
+		principalIDs = append(principalIDs, id)
+		if _, exists := names[p.Type()]; !exists {
+			names[p.Type()] = p.Name
+		}
+	}
+	for _, principalType := range externalUserPrincipalTypes {
+		d.Set(principalType, names[principalType])
 	}
-	return ""
+	return d.Set("principal_ids", principalIDs)
 }
 
-func newUserPassword(d *schema.ResourceData) (password string) {
-	if password = d.Get("password").(string); password == "" {
-		password = fmt.Sprintf("%d%d", rand.New(rand.NewSource(time.Now().UnixNano())).Int(), rand.New(rand.NewSource(time.Now().UnixNano())).Int())
+// localPrincipalIDs returns the principal IDs Rancher itself has assigned to the given user.
+func localPrincipalIDs(u *client.User) []string {
+	principalIDs := make([]string, 0, 1)
+	for _, id := range u.PrincipalIDs {
+		if p, err := parsePrincipalID(id); err == nil && p.Provider == localPrincipalProvider {
+			principalIDs = append(principalIDs, id)
+		}
 	}
-	return password
+	return principalIDs
+}
+
+// userGlobalRoleIDs returns the IDs of all global roles that have been bound to the given user.
+func userGlobalRoleIDs(c *client.Client, userID string) ([]string, error) {
+	bindings, err := globalRoleBindingsByUser(c, userID)
+	if err != nil {
+		return nil, err
+	}
+	globalRoleIDs := make([]string, 0, len(bindings))
+	for _, binding := range bindings {
+		globalRoleIDs = append(globalRoleIDs, binding.GlobalRoleID)
+	}
+	return globalRoleIDs, nil
+}
+
+// reconcileUserGlobalRoles binds all given global roles to the given user and removes all other global role
+// bindings of the user.
+func reconcileUserGlobalRoles(c *client.Client, userID string, globalRoleIDs []string) error {
+	bindings, err := globalRoleBindingsByUser(c, userID)
+	if err != nil {
+		return err
+	}
+	wanted := make(map[string]bool, len(globalRoleIDs))
+	for _, globalRoleID := range globalRoleIDs {
+		wanted[globalRoleID] = true
+	}
+	for i := range bindings {
+		if wanted[bindings[i].GlobalRoleID] {
+			delete(wanted, bindings[i].GlobalRoleID)
+			continue
+		}
+		if err := c.GlobalRoleBinding.Delete(&bindings[i]); err != nil {
+			return fmt.Errorf("unable to remove global role \"%s\" from user \"%s\": %v", bindings[i].GlobalRoleID, userID, err)
+		}
+	}
+	for globalRoleID := range wanted {
+		if _, err := c.GlobalRoleBinding.Create(&client.GlobalRoleBinding{
+			GlobalRoleID: globalRoleID,
+			UserID:       userID,
+		}); err != nil {
+			return fmt.Errorf("unable to bind global role \"%s\" to user \"%s\": %v", globalRoleID, userID, err)
+		}
+	}
+	return nil
+}
+
+func newUserPassword(d *schema.ResourceData) (string, error) {
+	if password := d.Get("password").(string); password != "" {
+		return password, nil
+	}
+	return generatePassword(expandPasswordPolicy(d.Get("password_policy")))
+}
+
+// userEnabled returns whether the given user is enabled. Users that do not say otherwise are enabled.
+func userEnabled(u *client.User) bool {
+	return u.Enabled == nil || *u.Enabled
 }
 
 func resourceUserCreate(d *schema.ResourceData, m interface{}) error {
@@ -54,28 +162,28 @@ func resourceUserCreate(d *schema.ResourceData, m interface{}) error {
 	username := d.Get("username").(string)
 
 	password := ""
+	var err error
 	if username != "" {
 		if user, err := userByUsername(rancher, username); err != nil {
 			return err
 		} else if user != nil {
 			return fmt.Errorf("user with username \"%s\" (ID: \"%s\") does already exist", username, user.ID)
 		}
-		password = newUserPassword(d)
+		if password, err = newUserPassword(d); err != nil {
+			return err
+		}
 	}
 
-	principalIDs := make([]string, 0)
-	activeDirectoryUser := d.Get("activedirectory_user").(string)
-	if activeDirectoryUser != "" {
-		principalIDs = append(principalIDs, fmt.Sprintf("activedirectory_user://%s", activeDirectoryUser))
-	}
+	enabled := d.Get("enabled").(bool)
 
 	user, err := rancher.User.Create(&client.User{
 		Username:           username,
 		Name:               d.Get("name").(string),
 		Description:        d.Get("description").(string),
-		PrincipalIDs:       principalIDs,
+		PrincipalIDs:       userPrincipalIDs(d),
 		Password:           password,
-		MustChangePassword: false,
+		MustChangePassword: d.Get("must_change_password").(bool),
+		Enabled:            &enabled,
 	})
 	if err != nil {
 		return err
@@ -87,9 +195,22 @@ func resourceUserCreate(d *schema.ResourceData, m interface{}) error {
 	d.Set("name", user.Name)
 	d.Set("password", password)
 	d.Set("description", user.Description)
-	d.Set("activedirectory_user", getActiveDirectoryUser(user))
+	d.Set("must_change_password", user.MustChangePassword)
+	d.Set("enabled", userEnabled(user))
+	if err := setUserPrincipalIDs(d, user); err != nil {
+		return err
+	}
 
-	return nil
+	if globalRoleIDs, ok := d.GetOk("global_role_ids"); ok {
+		if err := reconcileUserGlobalRoles(rancher, user.ID, expandStringSet(globalRoleIDs)); err != nil {
+			return err
+		}
+	}
+	globalRoleIDs, err := userGlobalRoleIDs(rancher, user.ID)
+	if err != nil {
+		return err
+	}
+	return d.Set("global_role_ids", globalRoleIDs)
 }
 
 func resourceUserRead(d *schema.ResourceData, m interface{}) error {
@@ -109,8 +230,17 @@ func resourceUserRead(d *schema.ResourceData, m interface{}) error {
 	d.Set("username", user.Username)
 	d.Set("name", user.Name)
 	d.Set("description", user.Description)
-	d.Set("activedirectory_user", getActiveDirectoryUser(user))
-	return nil
+	d.Set("must_change_password", user.MustChangePassword)
+	d.Set("enabled", userEnabled(user))
+	if err := setUserPrincipalIDs(d, user); err != nil {
+		return err
+	}
+
+	globalRoleIDs, err := userGlobalRoleIDs(rancher, user.ID)
+	if err != nil {
+		return err
+	}
+	return d.Set("global_role_ids", globalRoleIDs)
 }
 
 func resourceUserUpdate(d *schema.ResourceData, m interface{}) error {
@@ -132,8 +262,11 @@ func resourceUserUpdate(d *schema.ResourceData, m interface{}) error {
 	if d.HasChange("username") {
 		updates["username"] = d.Get("username").(string)
 	}
-	if d.HasChange("password") {
-		updates["password"] = d.Get("password").(string)
+	if d.HasChange("must_change_password") {
+		updates["mustChangePassword"] = d.Get("must_change_password").(bool)
+	}
+	if d.HasChange("enabled") {
+		updates["enabled"] = d.Get("enabled").(bool)
 	}
 	if d.HasChange("name") {
 		updates["name"] = d.Get("name").(string)
@@ -141,16 +274,37 @@ func resourceUserUpdate(d *schema.ResourceData, m interface{}) error {
 	if d.HasChange("description") {
 		updates["description"] = d.Get("description").(string)
 	}
-	if d.HasChange("activedirectory_user") {
-		updates["principalIDs"] = []string{
-			fmt.Sprintf("activedirectory_user://%s", d.Get("activedirectory_user").(string)),
-		}
+	if userPrincipalIDsChanged(d) {
+		// The local principal has to be kept, otherwise the user would be no longer be able to log in.
+		updates["principalIDs"] = append(localPrincipalIDs(user), userPrincipalIDs(d)...)
 	}
 
-	if _, err = rancher.User.Update(user, updates); err != nil {
+	if user, err = rancher.User.Update(user, updates); err != nil {
 		return err
 	}
 
+	if d.HasChange("password") || d.HasChange("password_wo_rotation") {
+		// Passwords cannot be updated like other attributes, Rancher requires us to call an action to do so.
+		password := d.Get("password").(string)
+		if !d.HasChange("password") && user.Username != "" {
+			if password, err = generatePassword(expandPasswordPolicy(d.Get("password_policy"))); err != nil {
+				return err
+			}
+		}
+		if password != "" {
+			if _, err = rancher.User.ActionSetpassword(user, &client.SetPasswordInput{NewPassword: password}); err != nil {
+				return err
+			}
+			d.Set("password", password)
+		}
+	}
+
+	if d.HasChange("global_role_ids") {
+		if err := reconcileUserGlobalRoles(rancher, id, expandStringSet(d.Get("global_role_ids"))); err != nil {
+			return err
+		}
+	}
+
 	d.Partial(false)
 
 	return nil
@@ -192,6 +346,18 @@ func resourceUserState(d *schema.ResourceData, m interface{}) ([]*schema.Resourc
 	return []*schema.ResourceData{d}, nil
 }
 
+// externalUserPrincipalSchema returns the schema of an attribute that associates a Rancher user with a user
+// of a specific external authentication provider.
+func externalUserPrincipalSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Description:   description,
+		Type:          schema.TypeString,
+		Optional:      true,
+		Computed:      true,
+		ConflictsWith: []string{"principal_ids"},
+	}
+}
+
 func resourceUser() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceUserCreate,
@@ -220,7 +386,7 @@ func resourceUser() *schema.Resource {
 				ForceNew:    true,
 			},
 			"password": {
-				Description: "Password of the Rancher user",
+				Description: "Password of the Rancher user (generated according to the password policy if not given)",
 				Type:        schema.TypeString,
 				Optional:    true,
 				Sensitive:   true,
@@ -228,13 +394,31 @@ func resourceUser() *schema.Resource {
 					return old != "" && new == ""
 				},
 			},
+			"password_policy": passwordPolicySchema(),
+			"password_wo_rotation": {
+				Description:   "Arbitrary map of values that, when changed, will trigger the generation of a new password",
+				Type:          schema.TypeMap,
+				Optional:      true,
+				ConflictsWith: []string{"password"},
+			},
+			"must_change_password": {
+				Description: "Whether the Rancher user has to change the password on the next login",
+				Type:        schema.TypeBool,
+				Optional:    true,
+			},
+			"enabled": {
+				Description: "Whether the Rancher user is allowed to log in",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
 			"name": {
 				Description: "Display name of the Rancher user",
 				Type:        schema.TypeString,
 				Optional:    true,
 				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
-					// Active directory user names seem to be being pre-populated
-					return d.Get("activedirectory_user") != "" && new == ""
+					// Names of users of external authentication providers seem to be being pre-populated
+					return len(userPrincipalIDs(d)) > 0 && new == ""
 				},
 			},
 			"description": {
@@ -242,10 +426,26 @@ func resourceUser() *schema.Resource {
 				Type:        schema.TypeString,
 				Optional:    true,
 			},
-			"activedirectory_user": {
-				Description: "Distinguished name (DN) of the associated ActiveDirectory user account",
-				Type:        schema.TypeString,
+			"principal_ids": {
+				Description:   "IDs of the external principals (e.g. \"openldap_user://uid=jdoe,ou=users\") associated with the Rancher user",
+				Type:          schema.TypeSet,
+				Optional:      true,
+				Computed:      true,
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				ConflictsWith: externalUserPrincipalTypes,
+			},
+			"activedirectory_user": externalUserPrincipalSchema("Distinguished name (DN) of the associated ActiveDirectory user account"),
+			"openldap_user":        externalUserPrincipalSchema("Distinguished name (DN) of the associated OpenLDAP user account"),
+			"freeipa_user":         externalUserPrincipalSchema("Distinguished name (DN) of the associated FreeIPA user account"),
+			"github_user":          externalUserPrincipalSchema("Numeric ID of the associated GitHub user account"),
+			"azuread_user":         externalUserPrincipalSchema("Object ID of the associated Azure AD user account"),
+			"ping_user":            externalUserPrincipalSchema("Name ID of the associated Ping Identity user account"),
+			"global_role_ids": {
+				Description: "IDs of the global roles (e.g. \"admin\" or \"user\") bound to the Rancher user",
+				Type:        schema.TypeSet,
 				Optional:    true,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
 			},
 		},
 	}
@@ -0,0 +1,45 @@
+package rancher2
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// conflictingAttributes returns all of the given mutually exclusive attributes except the given one.
+func conflictingAttributes(attributes []string, attribute string) []string {
+	conflicts := make([]string, 0, len(attributes)-1)
+	for _, a := range attributes {
+		if a != attribute {
+			conflicts = append(conflicts, a)
+		}
+	}
+	return conflicts
+}
+
+// mergeSchemas merges the given schemas into a single one.
+func mergeSchemas(schemas ...map[string]*schema.Schema) map[string]*schema.Schema {
+	merged := map[string]*schema.Schema{}
+	for _, s := range schemas {
+		for k, v := range s {
+			merged[k] = v
+		}
+	}
+	return merged
+}
+
+// configBlock returns the single block of a block list with at most one element, or nil if it is empty.
+func configBlock(v interface{}) map[string]interface{} {
+	list := v.([]interface{})
+	if len(list) == 0 || list[0] == nil {
+		return nil
+	}
+	return list[0].(map[string]interface{})
+}
+
+// reportedSecret returns the secret as reported by Rancher. If Rancher does not report it, we keep the
+// configured one.
+func reportedSecret(d *schema.ResourceData, key string, reported string) string {
+	if reported != "" {
+		return reported
+	}
+	return d.Get(key).(string)
+}